@@ -63,13 +63,24 @@
 // The following are the subcommands of twtr, with their command line syntax,
 // see the respective section of each subcommand for further information
 //
-//     twtr quickstart [-cfhnuv] [--disclose-identity] [--follow-news]
-//     twtr timeline   [-chv] [--limit COUNT] [--sort ascending|descending]
+//     twtr quickstart [-cfhnuv] [--disclose-identity] [--follow-news] [--gist TOKEN_FILE]
+//     twtr timeline   [-chv] [--limit COUNT] [--list NAME] [--raw] [--show-muted] [--sort ascending|descending] [--unread]
 //     twtr following  [-chv]
 //     twtr follow     [-chv] [--replace] SOURCE [SOURCES...]
 //     twtr unfollow   [-chv] SOURCE [SOURCES...]
-//     twtr tweet      [-cfhv] TWEET
-//     twtr view       [-chv] SOURCE [SOURCES...]
+//     twtr tweet      [-cfhv] [--draft] [--at TIME] TWEET
+//     twtr view       [-chv] [--raw] [--show-muted] SOURCE [SOURCES...]
+//     twtr search     [-chv] [--archives] [--from NICK] [--index] [--regex] [--since DATE] [--until DATE] QUERY
+//     twtr mark-read  [-chv] [--list NAME] [NICK...]
+//     twtr mentions   [-chv] [--raw] [--since DATE] [--unread]
+//     twtr discover   [-chv] [--follow NICK] [--limit COUNT]
+//     twtr followers  [-chv] [--stats] --log FILE [--log FILE...]
+//     twtr serve      [-cfhv] [--access-log FILE] [--addr ADDRESS] [--cert FILE --key FILE]
+//     twtr export     [-cfhv] --format atom|rss|jsonfeed [--timeline | SOURCE]
+//     twtr import     [-cfhv] mastodon|twitter FILE
+//     twtr delete     [-cfhv] HASH|INDEX
+//     twtr edit       [-cfhv] HASH|INDEX
+//     twtr drafts     [-cfhv] list|edit ID|post ID|rm ID|flush
 //     twtr config     [-chv] [--edit]|[--remove KEY]|[KEY [VALUE]]
 //
 // Note that the -c, -h, and -v flags are universal.
@@ -80,7 +91,7 @@
 //
 // Usage:
 //
-//     twtr quickstart [-cfhnuv] [--disclose-identity] [--follow-news]
+//     twtr quickstart [-cfhnuv] [--disclose-identity] [--follow-news] [--gist TOKEN_FILE]
 //
 // Options:
 //
@@ -88,29 +99,48 @@
 //         --disclose-identity  Show your nickname and url in the User Agent.
 //     -f, --file PATH          Specify a custom twtxt file location.
 //         --follow-news        Follow the official twtxt and twtr news feeds.
+//         --gist TOKEN_FILE    Host your feed in a new GitHub gist.
 //     -h, --help               Show this message and exit.
 //     -n, --nick NICK          Specify the nickname for your feed.
 //     -u, --url URL            Specify the url that your feed will be hosted at.
 //     -v, --verbose            Enable verbose output for debugging.
 //         --version            Show the version and exit.
 //
+// Gist:
+//
+// With --gist, a new public GitHub gist is created to host your feed, using the
+// GitHub token in TOKEN_FILE, and your twturl is set to the raw url of your
+// twtfile in the gist. The gist is updated after each tweet, see the [gist]
+// section of the CONFIGURATION.
+//
 // TIMELINE SYNOPSIS
 //
 // Retrieve your personal timeline.
 //
 // Usage:
 //
-//     twtr timeline [-chv] [--limit COUNT] [--sort ascending|descending]
+//     twtr timeline [-chv] [--limit COUNT] [--list NAME] [--raw] [--show-muted] [--sort ascending|descending] [--unread]
 //
 // Options:
 //
 //     -c, --config PATH     Specify a custom configuration file location.
 //     -h, --help            Show this message and exit.
 //         --limit COUNT     Limit the amount of tweets shown.
+//         --list NAME       Only show tweets from the sources in the list NAME.
+//         --raw             Show posts as written, without rendering Markdown.
+//         --show-muted      Show tweets hidden by your filters, marked as muted.
 //         --sort DIRECTION  Sort tweets ascending or descending by timestamp.
+//         --unread          Only show tweets that you haven't seen yet.
 //     -v, --verbose         Enable verbose output for debugging.
 //         --version         Show the version and exit.
 //
+// Unread:
+//
+// Tweets are marked as read once they are shown in your timeline. With
+// --unread, only the tweets that you haven't seen yet are shown, including new
+// tweets with older timestamps than those you have seen. Use mark-read to mark
+// tweets as read without showing them.
+//
 // FOLLOWING SYNOPSIS
 //
 // View the sources that you are following.
@@ -176,27 +206,38 @@
 //
 // Usage:
 //
-//     twtr tweet [-cfhv] TWEET
+//     twtr tweet [-cfhv] [--draft] [--at TIME] TWEET
 //
 // Options:
 //
+//         --at TIME      Schedule the tweet to be posted at TIME.
 //     -c, --config PATH  Specify a custom configuration file location.
+//         --draft        Save the tweet as a draft instead of posting it.
 //     -f, --file PATH    Specify a custom twtxt file location.
 //     -h, --help         Show this message and exit.
 //     -v, --verbose      Enable verbose output for debugging.
 //         --version      Show the version and exit.
 //
+// Drafts:
+//
+// With --draft, the tweet is saved as a draft to be posted later, see drafts.
+// With --at, the tweet is saved as a draft that is scheduled to be posted at
+// TIME, either a date and time in your local time zone, e.g. 2026-11-01T09:00,
+// or a timestamp, e.g. 2026-11-01T09:00:00+13:00.
+//
 // VIEW SYNOPSIS
 //
 // View a source that you follow.
 //
 // Usage:
 //
-//     twtr view [-chv] SOURCE [SOURCES...]
+//     twtr view [-chv] [--raw] [--show-muted] SOURCE [SOURCES...]
 //
 // Options:
 //     -c, --config PATH  Specify a custom configuration file location.
 //     -h, --help         Show this message and exit.
+//         --raw          Show posts as written, without rendering Markdown.
+//         --show-muted   Show tweets hidden by your filters, marked as muted.
 //     -v, --verbose      Enable verbose output for debugging.
 //         --version      Show the version and exit.
 //
@@ -207,6 +248,304 @@
 // you don't know the nickname of a SOURCE, you can make one up, or use the
 // domain part of the URL (this can be easily changed later).
 //
+// SEARCH SYNOPSIS
+//
+// Search the tweets of the sources that you follow.
+//
+// Usage:
+//
+//     twtr search [-chv] [--archives] [--from NICK] [--index] [--regex] [--since DATE] [--until DATE] QUERY
+//
+// Options:
+//
+//         --archives     Also search the archived feeds of each source.
+//     -c, --config PATH  Specify a custom configuration file location.
+//         --from NICK    Only show tweets from the source NICK.
+//     -h, --help         Show this message and exit.
+//         --index        Search the index of every tweet twtr has seen.
+//         --regex        Search with a regular expression.
+//         --since DATE   Only show tweets posted on or after DATE.
+//         --until DATE   Only show tweets posted before DATE.
+//     -v, --verbose      Enable verbose output for debugging.
+//         --version      Show the version and exit.
+//
+// Archives:
+//
+// With --archives, the archived feeds of each source are searched too. A feed
+// announces its newest archived feed with a prev field, e.g. # prev = 5vbi2ea
+// twtxt-2022-03.txt, and each archived feed may announce an older one, they are
+// fetched and cached like any other feed.
+//
+// Index:
+//
+// With --index, the QUERY is searched for in the index of every tweet that twtr
+// has fetched, which is much faster than reading each feed. All of the terms in
+// the QUERY have to match, unless separated by OR, quoted terms are phrases,
+// and from:NICK, tag:HASHTAG and url:URL match the source, hashtags, and feed
+// of each tweet.
+//
+// Query:
+//
+// The QUERY is searched for in the cached feeds of the sources that you follow,
+// ignoring case, or as a regular expression with --regex. Each DATE is either a
+// date, e.g. 2022-03-01, or a timestamp, e.g. 2022-03-01T09:30:00+13:00.
+//
+// MARK-READ SYNOPSIS
+//
+// Mark the tweets of the sources that you follow as read.
+//
+// Usage:
+//
+//     twtr mark-read [-chv] [--list NAME] [NICK...]
+//
+// Options:
+//
+//     -c, --config PATH  Specify a custom configuration file location.
+//     -h, --help         Show this message and exit.
+//         --list NAME    Only show tweets from the sources in the list NAME.
+//     -v, --verbose      Enable verbose output for debugging.
+//         --version      Show the version and exit.
+//
+// Sources:
+//
+// The tweets of each NICK that you follow are marked as read, or of every
+// source that you follow if no NICK is given. With --list, only the sources in
+// the list NAME are marked as read.
+//
+// MENTIONS SYNOPSIS
+//
+// View the tweets that mention you.
+//
+// Usage:
+//
+//     twtr mentions [-chv] [--raw] [--since DATE] [--unread]
+//
+// Options:
+//
+//     -c, --config PATH  Specify a custom configuration file location.
+//     -h, --help         Show this message and exit.
+//         --raw          Show posts as written, without rendering Markdown.
+//         --since DATE   Only show tweets posted on or after DATE.
+//         --unread       Only show tweets that you haven't seen yet.
+//     -v, --verbose      Enable verbose output for debugging.
+//         --version      Show the version and exit.
+//
+// Mentions:
+//
+// The tweets of the sources that you follow, and their cached feeds, that
+// mention your twturl as either @<nick url> or @<url> are shown newest first,
+// unread tweets are marked as new. The DATE is either a date, e.g. 2022-03-01,
+// or a timestamp, e.g. 2022-03-01T09:30:00+13:00.
+//
+// DISCOVER SYNOPSIS
+//
+// Discover new sources from the sources that you follow.
+//
+// Usage:
+//
+//     twtr discover [-chv] [--follow NICK] [--limit COUNT]
+//
+// Options:
+//
+//     -c, --config PATH  Specify a custom configuration file location.
+//         --follow NICK  Follow the suggested source NICK.
+//     -h, --help         Show this message and exit.
+//         --limit COUNT  Limit the amount of tweets shown.
+//     -v, --verbose      Enable verbose output for debugging.
+//         --version      Show the version and exit.
+//
+// Suggestions:
+//
+// The sources that you follow are searched for the feeds that they follow, with
+// the follow metadata field, and the feeds that they mention. Feeds that you
+// don't follow yet are ranked by how many of the sources that you follow
+// reference them, and how recently. Each suggestion is shown as a SOURCE that
+// can be given to follow, or followed with --follow.
+//
+// FOLLOWERS SYNOPSIS
+//
+// View the sources that follow you.
+//
+// Usage:
+//
+//     twtr followers [-chv] [--stats] --log FILE [--log FILE...]
+//
+// Options:
+//
+//     -c, --config PATH  Specify a custom configuration file location.
+//     -h, --help         Show this message and exit.
+//         --log FILE     Read the web server access log FILE.
+//         --stats        Show your followers as metadata fields for your feed.
+//     -v, --verbose      Enable verbose output for debugging.
+//         --version      Show the version and exit.
+//
+// Logs:
+//
+// Each FILE is an access log of the web server that hosts your feed, in the
+// common or combined log format, rotated logs compressed with gzip are also
+// read. Followers are the clients that fetched your twturl and announced
+// themselves in their User-Agent, e.g. as (+url; @nick), including each
+// follower of a yarnd pod. They are shown with their client and when they were
+// first and last seen.
+//
+// SERVE SYNOPSIS
+//
+// Host your feed with a built-in web server.
+//
+// Usage:
+//
+//     twtr serve [-cfhv] [--access-log FILE] [--addr ADDRESS] [--cert FILE --key FILE]
+//
+// Options:
+//
+//         --access-log FILE  Write an access log of each request to FILE.
+//         --addr ADDRESS     Listen on ADDRESS, e.g. localhost:8080.
+//         --cert FILE        Serve over TLS with the certificate in FILE.
+//     -c, --config PATH      Specify a custom configuration file location.
+//     -f, --file PATH        Specify a custom twtxt file location.
+//     -h, --help             Show this message and exit.
+//         --key FILE         Serve over TLS with the private key in FILE.
+//     -v, --verbose          Enable verbose output for debugging.
+//         --version          Show the version and exit.
+//
+// Server:
+//
+// Your twtfile is served at the path of your twturl, as text/plain;
+// charset=utf-8, with support for ETag, Last-Modified, and Range requests. The
+// ADDRESS defaults to localhost:8080, over plain HTTP unless both --cert and
+// --key are given. The User-Agent of each follower is logged, and an access log
+// written with --access-log can be read by followers --log. Your feed is also
+// exported at /atom.xml, /rss.xml, and /feed.json, see export.
+//
+// EXPORT SYNOPSIS
+//
+// Export a feed or your timeline for feed readers.
+//
+// Usage:
+//
+//     twtr export [-cfhv] --format atom|rss|jsonfeed [--timeline | SOURCE]
+//
+// Options:
+//
+//     -c, --config PATH    Specify a custom configuration file location.
+//     -f, --file PATH      Specify a custom twtxt file location.
+//         --format FORMAT  Export as FORMAT, one of atom, rss, or jsonfeed.
+//     -h, --help           Show this message and exit.
+//         --timeline       Export your personal timeline instead of a source.
+//     -v, --verbose        Enable verbose output for debugging.
+//         --version        Show the version and exit.
+//
+// Export:
+//
+// Your own feed is exported, unless a SOURCE that you follow is given, or your
+// personal timeline with --timeline. The nick, avatar, description, and link
+// metadata fields are mapped to the exported feed, and each tweet is identified
+// by its twt hash, so feed readers don't show it twice. The same feed is served
+// by serve at /atom.xml, /rss.xml, and /feed.json.
+//
+// IMPORT SYNOPSIS
+//
+// Import your posts from a Mastodon or Twitter archive.
+//
+// Usage:
+//
+//     twtr import [-cfhv] mastodon|twitter FILE
+//
+// Options:
+//
+//     -c, --config PATH  Specify a custom configuration file location.
+//     -f, --file PATH    Specify a custom twtxt file location.
+//     -h, --help         Show this message and exit.
+//     -v, --verbose      Enable verbose output for debugging.
+//         --version      Show the version and exit.
+//
+// Archives:
+//
+// The FILE is the outbox.json of a Mastodon archive, or the data/tweets.js of a
+// Twitter archive. Each public post is added to your twtfile with its original
+// timestamp, in chronological order, boosts, retweets, and private posts are
+// skipped. Mentions are kept as @<nick url>, with the url of the mentioned
+// profile, and shortened links are expanded. Posts that are already in your
+// twtfile are skipped, so importing an archive again is safe.
+//
+// DELETE SYNOPSIS
+//
+// Delete one of your tweets.
+//
+// Usage:
+//
+//     twtr delete [-cfhv] HASH|INDEX
+//
+// Options:
+//
+//     -c, --config PATH  Specify a custom configuration file location.
+//     -f, --file PATH    Specify a custom twtxt file location.
+//     -h, --help         Show this message and exit.
+//     -v, --verbose      Enable verbose output for debugging.
+//         --version      Show the version and exit.
+//
+// Tweets:
+//
+// The tweet is either its twt HASH, or its INDEX counting back from your newest
+// tweet, where 1 is the newest. Your twtfile is rewritten without the tweet,
+// keeping every other line as it is, and the pre and post tweet hooks are run,
+// e.g. to publish your twtfile.
+//
+// EDIT SYNOPSIS
+//
+// Edit one of your tweets.
+//
+// Usage:
+//
+//     twtr edit [-cfhv] HASH|INDEX
+//
+// Options:
+//
+//     -c, --config PATH  Specify a custom configuration file location.
+//     -f, --file PATH    Specify a custom twtxt file location.
+//     -h, --help         Show this message and exit.
+//     -v, --verbose      Enable verbose output for debugging.
+//         --version      Show the version and exit.
+//
+// Tweets:
+//
+// The tweet is either its twt HASH, or its INDEX counting back from your newest
+// tweet, where 1 is the newest. The text of the tweet is opened in your
+// $EDITOR, and the edited text replaces it in your twtfile, keeping its
+// original timestamp, and the pre and post tweet hooks are run. The twt hash of
+// the tweet changes with its text, so replies to the tweet still refer to its
+// old hash.
+//
+// DRAFTS SYNOPSIS
+//
+// Manage your drafts and scheduled tweets.
+//
+// Usage:
+//
+//     twtr drafts [-cfhv] list|edit ID|post ID|rm ID|flush
+//
+// Options:
+//
+//     -c, --config PATH  Specify a custom configuration file location.
+//     -f, --file PATH    Specify a custom twtxt file location.
+//     -h, --help         Show this message and exit.
+//     -v, --verbose      Enable verbose output for debugging.
+//         --version      Show the version and exit.
+//
+// Drafts:
+//
+// Drafts are saved with tweet --draft or tweet --at, and each one has an ID.
+// Use list to show your drafts, scheduled drafts first, edit to change the text
+// of a draft in your $EDITOR, post to post a draft now, and rm to remove a
+// draft without posting it.
+//
+// Schedule:
+//
+// Use flush to post every scheduled draft whose time has come, stamped with the
+// time that it was scheduled at, and run the pre and post tweet hooks. It is
+// meant to be run regularly, e.g. every few minutes by cron, and is silent
+// unless a draft is posted.
+//
 // CONFIG SYNOPSIS
 //
 // Update your configuration.
@@ -357,12 +696,110 @@
 //
 // The pre/post tweet hooks are executed as system commands, any occurrences of
 // "{foo}" will be replaced with the value of that configuration. For example,
-// "{twtfile}" will be replaced with the path to your local file.
+// "{twtfile}" will be replaced with the path to your local file. The hooks are
+// also run when you delete or edit one of your tweets.
 //
 // The [following] section contains all the sources you follow, the keys in
 // this section are the nicknames, and the values of those keys are the urls of
 // the twtxt files. You can update this section using the (un)follow commands.
 //
+// Sources can be followed over http, https, gemini, and gopher, gopher sources
+// have to be text files, e.g. gopher://example.com/0/~alice/twtxt.txt. Gemini
+// servers are trusted on first use, the certificate of each server is pinned in
+// a gemini_known_hosts file in the cache directory, e.g. ~/.cache/twtr, and a
+// server that presents a different certificate before the pinned one expires is
+// refused.
+//
+// Sources can also be files on the same machine, as a file url, e.g.
+// file:///home/alice/public_html/twtxt.txt, a path in your home directory, e.g.
+// ~/twtxt.txt, or a path relative to the directory of the config file. These
+// are read straight from disk, and are only read again once they are modified.
+//
+// Sources can be RSS, Atom, or JSON Feeds too, e.g. the release announcements
+// of a project that doesn't publish a twtxt file. The format is detected from
+// the feed, and each item becomes a tweet of its title and link, posted at the
+// date that it was published, so it shows up in your timeline like any other
+// tweet. Items without a date are skipped.
+//
+// Lists group the sources you follow, so that your timeline can be limited to
+// just the sources in one list, e.g. with "twtr timeline --list work". Each
+// list is a section of its own, with a comma separated list of nicks from the
+// [following] section.
+//
+//     [list "work"]
+//     nicks = alice, bob
+//
+//     [list "hobbies"]
+//     nicks = carol
+//
+// The optional [filters] section mutes tweets in your timeline, each key is a
+// name for the filter, and each value is the type of filter, followed by what
+// to match, with an optional date when the filter expires.
+//
+//     [filters]
+//     newsbot = nick newsbot
+//     spammer = url https://example.com/spam.txt
+//     outage  = thread abcdefg until 2022-03-01
+//     typo    = hash hijklmn
+//     deploys = regex (?i)deploy(ed|ing)? until 2022-03-01T09:00:00Z
+//
+// The nick and url filters mute everything from a source you follow, the hash
+// filter mutes a single tweet by its twt hash, and the thread filter mutes a
+// tweet and all of the replies to it. The regex filter mutes any tweet with
+// text that matches the regular expression, which may contain "#" and ";" as
+// only lines that start with them are comments.
+// Muted tweets can still be seen with the --show-muted flag.
+//
+// The optional [colors] section sets the styles used to display your timeline,
+// any style that is left unset uses the default shown below.
+//
+//     [colors]
+//     nick        = bold
+//     timestamp   = dim
+//     mention     = cyan
+//     own_mention = bold yellow
+//     hashtag     = magenta
+//     url         = underline blue
+//
+// Each style is a space separated list of attributes (bold, dim, italic,
+// underline, blink, reverse) and colors (black, red, green, yellow, blue,
+// magenta, cyan, white), colors can be prefixed with "bright-", or given as a
+// number from 0 to 255. Use "none" for no style at all.
+//
+// Colors are only used when the output is a terminal, and never in porcelain
+// mode, or when the NO_COLOR environment variable is set.
+//
+// The optional [publish] section uploads your twtfile after each tweet, with
+// an HTTP PUT to the url, as supported by WebDAV servers. This works without
+// any tools like scp, and can be used alongside the tweet hooks.
+//
+//     [publish]
+//     url           = https://dav.example.com/nickname/twtxt.txt
+//     username      = nickname
+//     password_file = path/to/password
+//     retries       = 3
+//
+// The password is read from the password_file, so that your config file does
+// not contain it. Failed uploads are retried, 3 times unless set by retries,
+// and an upload is refused if the file on the server was changed since twtr
+// last published it, so that edits made elsewhere are never overwritten. The
+// ETag of the last upload is kept with the rest of twtr's state, the first
+// upload replaces the file that is already at the url, if any.
+//
+// The optional [gist] section hosts your twtfile in a GitHub gist, which is
+// updated after each tweet. The quickstart wizard can create the gist for you
+// with the --gist flag.
+//
+//     [gist]
+//     id         = aa5a315d61ae9438b18d
+//     file       = twtxt.txt
+//     token_file = path/to/token
+//     api_url    = https://api.github.com
+//
+// The token_file contains a GitHub token with the gist scope, and the file is
+// the name of your twtfile in the gist, twtxt.txt unless set. The api_url is
+// only needed for GitHub Enterprise, or to test against another server.
+//
 // ENVIRONMENT
 //
 // This is the user configuration directory used for the twtxt config file, it
@@ -371,6 +808,11 @@
 //
 //     XDG_CONFIG_HOME
 //
+// If this is set to any value, colors are disabled, see https://no-color.org
+// for more information.
+//
+//     NO_COLOR
+//
 // CONFORMING TO
 //
 // twtr conforms to the twtxt file specification, traditionally the file is
@@ -4,5 +4,8 @@ go 1.17
 
 require (
 	github.com/google/go-cmp v0.5.7
+	golang.org/x/crypto v0.0.0-20220214200702-86341886e292
 	gopkg.in/ini.v1 v1.66.2
 )
+
+require golang.org/x/sys v0.0.0-20210615035016-665e8c7367d1 // indirect
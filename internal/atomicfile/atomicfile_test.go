@@ -0,0 +1,51 @@
+package atomicfile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state", "drafts.json")
+
+	for _, data := range []string{"first", "second"} {
+		if err := WriteFile(path, []byte(data), 0o600); err != nil {
+			t.Fatalf("unexpected error: %q", err)
+		}
+
+		have, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %q", err)
+		}
+
+		if string(have) != data {
+			t.Errorf("have %q, want %q", have, data)
+		}
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	if have, want := info.Mode().Perm(), os.FileMode(0o600); have != want {
+		t.Errorf("have mode %s, want %s", have, want)
+	}
+
+	// no temporary files are left behind
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	if have, want := len(entries), 1; have != want {
+		t.Errorf("have %d files, want %d", have, want)
+	}
+
+	// the file can't be written into a directory that is a file
+	if err := WriteFile(filepath.Join(path, "drafts.json"), []byte("third"), 0o600); err == nil {
+		t.Errorf("expected an error")
+	}
+}
@@ -133,32 +133,43 @@ func (c command) help(ctx *Context) string {
 var (
 	quickstartCommand command = command{
 		name:        "quickstart",
-		usage:       "[-cfhnuv] [--disclose-identity] [--follow-news]",
+		usage:       "[-cfhnuv] [--disclose-identity] [--follow-news] [--gist TOKEN_FILE]",
 		description: "Quickstart wizard for setting up twtxt.",
 		flags: []flag{
 			configFlag,
 			discloseIdentityFlag,
 			fileFlag,
 			followNewsFlag,
+			gistFlag,
 			helpFlag,
 			nickFlag,
 			urlFlag,
 			verboseFlag,
 			versionFlag,
 		},
+		other: map[string]string{
+			"Gist": "With --gist, a new public GitHub gist is created to host your feed, using the GitHub token in TOKEN_FILE, and your twturl is set to the raw url of your twtfile in the gist. The gist is updated after each tweet, see the [gist] section of the CONFIGURATION.",
+		},
 	}
 	timelineCommand command = command{
 		name:        "timeline",
-		usage:       "[-chv] [--limit COUNT] [--sort ascending|descending]",
+		usage:       "[-chv] [--limit COUNT] [--list NAME] [--raw] [--show-muted] [--sort ascending|descending] [--unread]",
 		description: "Retrieve your personal timeline.",
 		flags: []flag{
 			configFlag,
 			helpFlag,
 			limitFlag,
+			listFlag,
+			rawFlag,
+			showMutedFlag,
 			sortFlag,
+			unreadFlag,
 			verboseFlag,
 			versionFlag,
 		},
+		other: map[string]string{
+			"Unread": "Tweets are marked as read once they are shown in your timeline. With --unread, only the tweets that you haven't seen yet are shown, including new tweets with older timestamps than those you have seen. Use mark-read to mark tweets as read without showing them.",
+		},
 	}
 	followingCommand command = command{
 		name:        "following",
@@ -199,23 +210,30 @@ var (
 	}
 	tweetCommand command = command{
 		name:        "tweet",
-		usage:       "[-cfhv] TWEET",
+		usage:       "[-cfhv] [--draft] [--at TIME] TWEET",
 		description: "Send out a message into the void.",
 		flags: []flag{
+			atFlag,
 			configFlag,
+			draftFlag,
 			fileFlag,
 			helpFlag,
 			verboseFlag,
 			versionFlag,
 		},
+		other: map[string]string{
+			"Drafts": "With --draft, the tweet is saved as a draft to be posted later, see drafts. With --at, the tweet is saved as a draft that is scheduled to be posted at TIME, either a date and time in your local time zone, e.g. 2026-11-01T09:00, or a timestamp, e.g. 2026-11-01T09:00:00+13:00.",
+		},
 	}
 	viewCommand command = command{
 		name:        "view",
-		usage:       "[-chv] SOURCE [SOURCES...]",
+		usage:       "[-chv] [--raw] [--show-muted] SOURCE [SOURCES...]",
 		description: "View a source that you follow.",
 		flags: []flag{
 			configFlag,
 			helpFlag,
+			rawFlag,
+			showMutedFlag,
 			verboseFlag,
 			versionFlag,
 		},
@@ -223,6 +241,189 @@ var (
 			"Sources": "At least one SOURCE must be given (unless called with -h), each SOURCE consists of a NICK and a URL. Allowed formats are NICK@URL or NICK URL, if you don't know the nickname of a SOURCE, you can make one up, or use the domain part of the URL (this can be easily changed later).",
 		},
 	}
+	searchCommand command = command{
+		name:        "search",
+		usage:       "[-chv] [--archives] [--from NICK] [--index] [--regex] [--since DATE] [--until DATE] QUERY",
+		description: "Search the tweets of the sources that you follow.",
+		flags: []flag{
+			archivesFlag,
+			configFlag,
+			fromFlag,
+			helpFlag,
+			indexFlag,
+			regexFlag,
+			sinceFlag,
+			untilFlag,
+			verboseFlag,
+			versionFlag,
+		},
+		other: map[string]string{
+			"Archives": "With --archives, the archived feeds of each source are searched too. A feed announces its newest archived feed with a prev field, e.g. # prev = 5vbi2ea twtxt-2022-03.txt, and each archived feed may announce an older one, they are fetched and cached like any other feed.",
+			"Query":    "The QUERY is searched for in the cached feeds of the sources that you follow, ignoring case, or as a regular expression with --regex. Each DATE is either a date, e.g. 2022-03-01, or a timestamp, e.g. 2022-03-01T09:30:00+13:00.",
+			"Index":    "With --index, the QUERY is searched for in the index of every tweet that twtr has fetched, which is much faster than reading each feed. All of the terms in the QUERY have to match, unless separated by OR, quoted terms are phrases, and from:NICK, tag:HASHTAG and url:URL match the source, hashtags, and feed of each tweet.",
+		},
+	}
+	markReadCommand command = command{
+		name:        "mark-read",
+		usage:       "[-chv] [--list NAME] [NICK...]",
+		description: "Mark the tweets of the sources that you follow as read.",
+		flags: []flag{
+			configFlag,
+			helpFlag,
+			listFlag,
+			verboseFlag,
+			versionFlag,
+		},
+		other: map[string]string{
+			"Sources": "The tweets of each NICK that you follow are marked as read, or of every source that you follow if no NICK is given. With --list, only the sources in the list NAME are marked as read.",
+		},
+	}
+	mentionsCommand command = command{
+		name:        "mentions",
+		usage:       "[-chv] [--raw] [--since DATE] [--unread]",
+		description: "View the tweets that mention you.",
+		flags: []flag{
+			configFlag,
+			helpFlag,
+			rawFlag,
+			sinceFlag,
+			unreadFlag,
+			verboseFlag,
+			versionFlag,
+		},
+		other: map[string]string{
+			"Mentions": "The tweets of the sources that you follow, and their cached feeds, that mention your twturl as either @<nick url> or @<url> are shown newest first, unread tweets are marked as new. The DATE is either a date, e.g. 2022-03-01, or a timestamp, e.g. 2022-03-01T09:30:00+13:00.",
+		},
+	}
+	discoverCommand command = command{
+		name:        "discover",
+		usage:       "[-chv] [--follow NICK] [--limit COUNT]",
+		description: "Discover new sources from the sources that you follow.",
+		flags: []flag{
+			configFlag,
+			followFlag,
+			helpFlag,
+			limitFlag,
+			verboseFlag,
+			versionFlag,
+		},
+		other: map[string]string{
+			"Suggestions": "The sources that you follow are searched for the feeds that they follow, with the follow metadata field, and the feeds that they mention. Feeds that you don't follow yet are ranked by how many of the sources that you follow reference them, and how recently. Each suggestion is shown as a SOURCE that can be given to follow, or followed with --follow.",
+		},
+	}
+	followersCommand command = command{
+		name:        "followers",
+		usage:       "[-chv] [--stats] --log FILE [--log FILE...]",
+		description: "View the sources that follow you.",
+		flags: []flag{
+			configFlag,
+			helpFlag,
+			logFlag,
+			statsFlag,
+			verboseFlag,
+			versionFlag,
+		},
+		other: map[string]string{
+			"Logs": "Each FILE is an access log of the web server that hosts your feed, in the common or combined log format, rotated logs compressed with gzip are also read. Followers are the clients that fetched your twturl and announced themselves in their User-Agent, e.g. as (+url; @nick), including each follower of a yarnd pod. They are shown with their client and when they were first and last seen.",
+		},
+	}
+	serveCommand command = command{
+		name:        "serve",
+		usage:       "[-cfhv] [--access-log FILE] [--addr ADDRESS] [--cert FILE --key FILE]",
+		description: "Host your feed with a built-in web server.",
+		flags: []flag{
+			accessLogFlag,
+			addrFlag,
+			certFlag,
+			configFlag,
+			fileFlag,
+			helpFlag,
+			keyFlag,
+			verboseFlag,
+			versionFlag,
+		},
+		other: map[string]string{
+			"Server": "Your twtfile is served at the path of your twturl, as text/plain; charset=utf-8, with support for ETag, Last-Modified, and Range requests. The ADDRESS defaults to localhost:8080, over plain HTTP unless both --cert and --key are given. The User-Agent of each follower is logged, and an access log written with --access-log can be read by followers --log. Your feed is also exported at /atom.xml, /rss.xml, and /feed.json, see export.",
+		},
+	}
+	exportCommand command = command{
+		name:        "export",
+		usage:       "[-cfhv] --format atom|rss|jsonfeed [--timeline | SOURCE]",
+		description: "Export a feed or your timeline for feed readers.",
+		flags: []flag{
+			configFlag,
+			fileFlag,
+			formatFlag,
+			helpFlag,
+			timelineFlag,
+			verboseFlag,
+			versionFlag,
+		},
+		other: map[string]string{
+			"Export": "Your own feed is exported, unless a SOURCE that you follow is given, or your personal timeline with --timeline. The nick, avatar, description, and link metadata fields are mapped to the exported feed, and each tweet is identified by its twt hash, so feed readers don't show it twice. The same feed is served by serve at /atom.xml, /rss.xml, and /feed.json.",
+		},
+	}
+	importCommand command = command{
+		name:        "import",
+		usage:       "[-cfhv] mastodon|twitter FILE",
+		description: "Import your posts from a Mastodon or Twitter archive.",
+		flags: []flag{
+			configFlag,
+			fileFlag,
+			helpFlag,
+			verboseFlag,
+			versionFlag,
+		},
+		other: map[string]string{
+			"Archives": "The FILE is the outbox.json of a Mastodon archive, or the data/tweets.js of a Twitter archive. Each public post is added to your twtfile with its original timestamp, in chronological order, boosts, retweets, and private posts are skipped. Mentions are kept as @<nick url>, with the url of the mentioned profile, and shortened links are expanded. Posts that are already in your twtfile are skipped, so importing an archive again is safe.",
+		},
+	}
+	deleteCommand command = command{
+		name:        "delete",
+		usage:       "[-cfhv] HASH|INDEX",
+		description: "Delete one of your tweets.",
+		flags: []flag{
+			configFlag,
+			fileFlag,
+			helpFlag,
+			verboseFlag,
+			versionFlag,
+		},
+		other: map[string]string{
+			"Tweets": "The tweet is either its twt HASH, or its INDEX counting back from your newest tweet, where 1 is the newest. Your twtfile is rewritten without the tweet, keeping every other line as it is, and the pre and post tweet hooks are run, e.g. to publish your twtfile.",
+		},
+	}
+	editCommand command = command{
+		name:        "edit",
+		usage:       "[-cfhv] HASH|INDEX",
+		description: "Edit one of your tweets.",
+		flags: []flag{
+			configFlag,
+			fileFlag,
+			helpFlag,
+			verboseFlag,
+			versionFlag,
+		},
+		other: map[string]string{
+			"Tweets": "The tweet is either its twt HASH, or its INDEX counting back from your newest tweet, where 1 is the newest. The text of the tweet is opened in your $EDITOR, and the edited text replaces it in your twtfile, keeping its original timestamp, and the pre and post tweet hooks are run. The twt hash of the tweet changes with its text, so replies to the tweet still refer to its old hash.",
+		},
+	}
+	draftsCommand command = command{
+		name:        "drafts",
+		usage:       "[-cfhv] list|edit ID|post ID|rm ID|flush",
+		description: "Manage your drafts and scheduled tweets.",
+		flags: []flag{
+			configFlag,
+			fileFlag,
+			helpFlag,
+			verboseFlag,
+			versionFlag,
+		},
+		other: map[string]string{
+			"Drafts":   "Drafts are saved with tweet --draft or tweet --at, and each one has an ID. Use list to show your drafts, scheduled drafts first, edit to change the text of a draft in your $EDITOR, post to post a draft now, and rm to remove a draft without posting it.",
+			"Schedule": "Use flush to post every scheduled draft whose time has come, stamped with the time that it was scheduled at, and run the pre and post tweet hooks. It is meant to be run regularly, e.g. every few minutes by cron, and is silent unless a draft is posted.",
+		},
+	}
 	configCommand command = command{
 		name:        "config",
 		usage:       "[-chv] [--edit]|[--remove KEY]|[KEY [VALUE]]",
@@ -246,5 +447,16 @@ var commands map[string]command = map[string]command{
 	unfollowCommand.name:   unfollowCommand,
 	tweetCommand.name:      tweetCommand,
 	viewCommand.name:       viewCommand,
+	searchCommand.name:     searchCommand,
+	markReadCommand.name:   markReadCommand,
+	mentionsCommand.name:   mentionsCommand,
+	discoverCommand.name:   discoverCommand,
+	followersCommand.name:  followersCommand,
+	serveCommand.name:      serveCommand,
+	exportCommand.name:     exportCommand,
+	importCommand.name:     importCommand,
+	deleteCommand.name:     deleteCommand,
+	editCommand.name:       editCommand,
+	draftsCommand.name:     draftsCommand,
 	configCommand.name:     configCommand,
 }
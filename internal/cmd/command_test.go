@@ -13,7 +13,7 @@ func TestCommand(t *testing.T) {
 	}{
 		{
 			command: quickstartCommand,
-			help: `Usage: twtr quickstart [-cfhnuv] [--disclose-identity] [--follow-news]
+			help: `Usage: twtr quickstart [-cfhnuv] [--disclose-identity] [--follow-news] [--gist TOKEN_FILE]
 
 Quickstart wizard for setting up twtxt.
 
@@ -22,16 +22,23 @@ Options:
 	    --disclose-identity  Show your nickname and url in the User Agent.
 	-f, --file PATH          Specify a custom twtxt file location.
 	    --follow-news        Follow the official twtxt and twtr news feeds.
+	    --gist TOKEN_FILE    Host your feed in a new GitHub gist.
 	-h, --help               Show this message and exit.
 	-n, --nick NICK          Specify the nickname for your feed.
 	-u, --url URL            Specify the url that your feed will be hosted at.
 	-v, --verbose            Enable verbose output for debugging.
 	    --version            Show the version and exit.
+
+Gist:
+	With --gist, a new public GitHub gist is created to host your feed,
+	using the GitHub token in TOKEN_FILE, and your twturl is set to the raw
+	url of your twtfile in the gist. The gist is updated after each tweet,
+	see the [gist] section of the CONFIGURATION.
 `,
 		},
 		{
 			command: timelineCommand,
-			help: `Usage: twtr timeline [-chv] [--limit COUNT] [--sort ascending|descending]
+			help: `Usage: twtr timeline [-chv] [--limit COUNT] [--list NAME] [--raw] [--show-muted] [--sort ascending|descending] [--unread]
 
 Retrieve your personal timeline.
 
@@ -39,9 +46,19 @@ Options:
 	-c, --config PATH     Specify a custom configuration file location.
 	-h, --help            Show this message and exit.
 	    --limit COUNT     Limit the amount of tweets shown.
+	    --list NAME       Only show tweets from the sources in the list NAME.
+	    --raw             Show posts as written, without rendering Markdown.
+	    --show-muted      Show tweets hidden by your filters, marked as muted.
 	    --sort DIRECTION  Sort tweets ascending or descending by timestamp.
+	    --unread          Only show tweets that you haven't seen yet.
 	-v, --verbose         Enable verbose output for debugging.
 	    --version         Show the version and exit.
+
+Unread:
+	Tweets are marked as read once they are shown in your timeline. With
+	--unread, only the tweets that you haven't seen yet are shown,
+	including new tweets with older timestamps than those you have seen.
+	Use mark-read to mark tweets as read without showing them.
 `,
 		},
 		{
@@ -92,27 +109,37 @@ Options:
 		},
 		{
 			command: tweetCommand,
-			help: `Usage: twtr tweet [-cfhv] TWEET
+			help: `Usage: twtr tweet [-cfhv] [--draft] [--at TIME] TWEET
 
 Send out a message into the void.
 
 Options:
+	    --at TIME      Schedule the tweet to be posted at TIME.
 	-c, --config PATH  Specify a custom configuration file location.
+	    --draft        Save the tweet as a draft instead of posting it.
 	-f, --file PATH    Specify a custom twtxt file location.
 	-h, --help         Show this message and exit.
 	-v, --verbose      Enable verbose output for debugging.
 	    --version      Show the version and exit.
+
+Drafts:
+	With --draft, the tweet is saved as a draft to be posted later, see
+	drafts. With --at, the tweet is saved as a draft that is scheduled to
+	be posted at TIME, either a date and time in your local time zone, e.g.
+	2026-11-01T09:00, or a timestamp, e.g. 2026-11-01T09:00:00+13:00.
 `,
 		},
 		{
 			command: viewCommand,
-			help: `Usage: twtr view [-chv] SOURCE [SOURCES...]
+			help: `Usage: twtr view [-chv] [--raw] [--show-muted] SOURCE [SOURCES...]
 
 View a source that you follow.
 
 Options:
 	-c, --config PATH  Specify a custom configuration file location.
 	-h, --help         Show this message and exit.
+	    --raw          Show posts as written, without rendering Markdown.
+	    --show-muted   Show tweets hidden by your filters, marked as muted.
 	-v, --verbose      Enable verbose output for debugging.
 	    --version      Show the version and exit.
 
@@ -121,6 +148,273 @@ Sources:
 	consists of a NICK and a URL. Allowed formats are NICK@URL or NICK URL,
 	if you don't know the nickname of a SOURCE, you can make one up, or use
 	the domain part of the URL (this can be easily changed later).
+`,
+		},
+		{
+			command: searchCommand,
+			help: `Usage: twtr search [-chv] [--archives] [--from NICK] [--index] [--regex] [--since DATE] [--until DATE] QUERY
+
+Search the tweets of the sources that you follow.
+
+Options:
+	    --archives     Also search the archived feeds of each source.
+	-c, --config PATH  Specify a custom configuration file location.
+	    --from NICK    Only show tweets from the source NICK.
+	-h, --help         Show this message and exit.
+	    --index        Search the index of every tweet twtr has seen.
+	    --regex        Search with a regular expression.
+	    --since DATE   Only show tweets posted on or after DATE.
+	    --until DATE   Only show tweets posted before DATE.
+	-v, --verbose      Enable verbose output for debugging.
+	    --version      Show the version and exit.
+
+Archives:
+	With --archives, the archived feeds of each source are searched too. A
+	feed announces its newest archived feed with a prev field, e.g. # prev
+	= 5vbi2ea twtxt-2022-03.txt, and each archived feed may announce an
+	older one, they are fetched and cached like any other feed.
+
+Index:
+	With --index, the QUERY is searched for in the index of every tweet
+	that twtr has fetched, which is much faster than reading each feed. All
+	of the terms in the QUERY have to match, unless separated by OR, quoted
+	terms are phrases, and from:NICK, tag:HASHTAG and url:URL match the
+	source, hashtags, and feed of each tweet.
+
+Query:
+	The QUERY is searched for in the cached feeds of the sources that you
+	follow, ignoring case, or as a regular expression with --regex. Each
+	DATE is either a date, e.g. 2022-03-01, or a timestamp, e.g.
+	2022-03-01T09:30:00+13:00.
+`,
+		},
+		{
+			command: markReadCommand,
+			help: `Usage: twtr mark-read [-chv] [--list NAME] [NICK...]
+
+Mark the tweets of the sources that you follow as read.
+
+Options:
+	-c, --config PATH  Specify a custom configuration file location.
+	-h, --help         Show this message and exit.
+	    --list NAME    Only show tweets from the sources in the list NAME.
+	-v, --verbose      Enable verbose output for debugging.
+	    --version      Show the version and exit.
+
+Sources:
+	The tweets of each NICK that you follow are marked as read, or of every
+	source that you follow if no NICK is given. With --list, only the
+	sources in the list NAME are marked as read.
+`,
+		},
+		{
+			command: mentionsCommand,
+			help: `Usage: twtr mentions [-chv] [--raw] [--since DATE] [--unread]
+
+View the tweets that mention you.
+
+Options:
+	-c, --config PATH  Specify a custom configuration file location.
+	-h, --help         Show this message and exit.
+	    --raw          Show posts as written, without rendering Markdown.
+	    --since DATE   Only show tweets posted on or after DATE.
+	    --unread       Only show tweets that you haven't seen yet.
+	-v, --verbose      Enable verbose output for debugging.
+	    --version      Show the version and exit.
+
+Mentions:
+	The tweets of the sources that you follow, and their cached feeds, that
+	mention your twturl as either @<nick url> or @<url> are shown newest
+	first, unread tweets are marked as new. The DATE is either a date, e.g.
+	2022-03-01, or a timestamp, e.g. 2022-03-01T09:30:00+13:00.
+`,
+		},
+		{
+			command: discoverCommand,
+			help: `Usage: twtr discover [-chv] [--follow NICK] [--limit COUNT]
+
+Discover new sources from the sources that you follow.
+
+Options:
+	-c, --config PATH  Specify a custom configuration file location.
+	    --follow NICK  Follow the suggested source NICK.
+	-h, --help         Show this message and exit.
+	    --limit COUNT  Limit the amount of tweets shown.
+	-v, --verbose      Enable verbose output for debugging.
+	    --version      Show the version and exit.
+
+Suggestions:
+	The sources that you follow are searched for the feeds that they
+	follow, with the follow metadata field, and the feeds that they
+	mention. Feeds that you don't follow yet are ranked by how many of the
+	sources that you follow reference them, and how recently. Each
+	suggestion is shown as a SOURCE that can be given to follow, or
+	followed with --follow.
+`,
+		},
+		{
+			command: followersCommand,
+			help: `Usage: twtr followers [-chv] [--stats] --log FILE [--log FILE...]
+
+View the sources that follow you.
+
+Options:
+	-c, --config PATH  Specify a custom configuration file location.
+	-h, --help         Show this message and exit.
+	    --log FILE     Read the web server access log FILE.
+	    --stats        Show your followers as metadata fields for your feed.
+	-v, --verbose      Enable verbose output for debugging.
+	    --version      Show the version and exit.
+
+Logs:
+	Each FILE is an access log of the web server that hosts your feed, in
+	the common or combined log format, rotated logs compressed with gzip
+	are also read. Followers are the clients that fetched your twturl and
+	announced themselves in their User-Agent, e.g. as (+url; @nick),
+	including each follower of a yarnd pod. They are shown with their
+	client and when they were first and last seen.
+`,
+		},
+		{
+			command: serveCommand,
+			help: `Usage: twtr serve [-cfhv] [--access-log FILE] [--addr ADDRESS] [--cert FILE --key FILE]
+
+Host your feed with a built-in web server.
+
+Options:
+	    --access-log FILE  Write an access log of each request to FILE.
+	    --addr ADDRESS     Listen on ADDRESS, e.g. localhost:8080.
+	    --cert FILE        Serve over TLS with the certificate in FILE.
+	-c, --config PATH      Specify a custom configuration file location.
+	-f, --file PATH        Specify a custom twtxt file location.
+	-h, --help             Show this message and exit.
+	    --key FILE         Serve over TLS with the private key in FILE.
+	-v, --verbose          Enable verbose output for debugging.
+	    --version          Show the version and exit.
+
+Server:
+	Your twtfile is served at the path of your twturl, as text/plain;
+	charset=utf-8, with support for ETag, Last-Modified, and Range
+	requests. The ADDRESS defaults to localhost:8080, over plain HTTP
+	unless both --cert and --key are given. The User-Agent of each follower
+	is logged, and an access log written with --access-log can be read by
+	followers --log. Your feed is also exported at /atom.xml, /rss.xml, and
+	/feed.json, see export.
+`,
+		},
+		{
+			command: exportCommand,
+			help: `Usage: twtr export [-cfhv] --format atom|rss|jsonfeed [--timeline | SOURCE]
+
+Export a feed or your timeline for feed readers.
+
+Options:
+	-c, --config PATH    Specify a custom configuration file location.
+	-f, --file PATH      Specify a custom twtxt file location.
+	    --format FORMAT  Export as FORMAT, one of atom, rss, or jsonfeed.
+	-h, --help           Show this message and exit.
+	    --timeline       Export your personal timeline instead of a source.
+	-v, --verbose        Enable verbose output for debugging.
+	    --version        Show the version and exit.
+
+Export:
+	Your own feed is exported, unless a SOURCE that you follow is given, or
+	your personal timeline with --timeline. The nick, avatar, description,
+	and link metadata fields are mapped to the exported feed, and each
+	tweet is identified by its twt hash, so feed readers don't show it
+	twice. The same feed is served by serve at /atom.xml, /rss.xml, and
+	/feed.json.
+`,
+		},
+		{
+			command: importCommand,
+			help: `Usage: twtr import [-cfhv] mastodon|twitter FILE
+
+Import your posts from a Mastodon or Twitter archive.
+
+Options:
+	-c, --config PATH  Specify a custom configuration file location.
+	-f, --file PATH    Specify a custom twtxt file location.
+	-h, --help         Show this message and exit.
+	-v, --verbose      Enable verbose output for debugging.
+	    --version      Show the version and exit.
+
+Archives:
+	The FILE is the outbox.json of a Mastodon archive, or the
+	data/tweets.js of a Twitter archive. Each public post is added to your
+	twtfile with its original timestamp, in chronological order, boosts,
+	retweets, and private posts are skipped. Mentions are kept as @<nick
+	url>, with the url of the mentioned profile, and shortened links are
+	expanded. Posts that are already in your twtfile are skipped, so
+	importing an archive again is safe.
+`,
+		},
+		{
+			command: deleteCommand,
+			help: `Usage: twtr delete [-cfhv] HASH|INDEX
+
+Delete one of your tweets.
+
+Options:
+	-c, --config PATH  Specify a custom configuration file location.
+	-f, --file PATH    Specify a custom twtxt file location.
+	-h, --help         Show this message and exit.
+	-v, --verbose      Enable verbose output for debugging.
+	    --version      Show the version and exit.
+
+Tweets:
+	The tweet is either its twt HASH, or its INDEX counting back from your
+	newest tweet, where 1 is the newest. Your twtfile is rewritten without
+	the tweet, keeping every other line as it is, and the pre and post
+	tweet hooks are run, e.g. to publish your twtfile.
+`,
+		},
+		{
+			command: editCommand,
+			help: `Usage: twtr edit [-cfhv] HASH|INDEX
+
+Edit one of your tweets.
+
+Options:
+	-c, --config PATH  Specify a custom configuration file location.
+	-f, --file PATH    Specify a custom twtxt file location.
+	-h, --help         Show this message and exit.
+	-v, --verbose      Enable verbose output for debugging.
+	    --version      Show the version and exit.
+
+Tweets:
+	The tweet is either its twt HASH, or its INDEX counting back from your
+	newest tweet, where 1 is the newest. The text of the tweet is opened in
+	your $EDITOR, and the edited text replaces it in your twtfile, keeping
+	its original timestamp, and the pre and post tweet hooks are run. The
+	twt hash of the tweet changes with its text, so replies to the tweet
+	still refer to its old hash.
+`,
+		},
+		{
+			command: draftsCommand,
+			help: `Usage: twtr drafts [-cfhv] list|edit ID|post ID|rm ID|flush
+
+Manage your drafts and scheduled tweets.
+
+Options:
+	-c, --config PATH  Specify a custom configuration file location.
+	-f, --file PATH    Specify a custom twtxt file location.
+	-h, --help         Show this message and exit.
+	-v, --verbose      Enable verbose output for debugging.
+	    --version      Show the version and exit.
+
+Drafts:
+	Drafts are saved with tweet --draft or tweet --at, and each one has an
+	ID. Use list to show your drafts, scheduled drafts first, edit to
+	change the text of a draft in your $EDITOR, post to post a draft now,
+	and rm to remove a draft without posting it.
+
+Schedule:
+	Use flush to post every scheduled draft whose time has come, stamped
+	with the time that it was scheduled at, and run the pre and post tweet
+	hooks. It is meant to be run regularly, e.g. every few minutes by cron,
+	and is silent unless a draft is posted.
 `,
 		},
 		{
@@ -22,4 +22,26 @@ var (
 	replaceFlag          flag = flag{"", "--replace", "", "Replace duplicates instead of returning an error."}
 	editFlag             flag = flag{"", "--edit", "", "Edit the configuration file manually."}
 	removeFlag           flag = flag{"", "--remove", "KEY", "Remove a configuration by its KEY, e.g. twtxt.nick."}
+	rawFlag              flag = flag{"", "--raw", "", "Show posts as written, without rendering Markdown."}
+	listFlag             flag = flag{"", "--list", "NAME", "Only show tweets from the sources in the list NAME."}
+	showMutedFlag        flag = flag{"", "--show-muted", "", "Show tweets hidden by your filters, marked as muted."}
+	archivesFlag         flag = flag{"", "--archives", "", "Also search the archived feeds of each source."}
+	fromFlag             flag = flag{"", "--from", "NICK", "Only show tweets from the source NICK."}
+	regexFlag            flag = flag{"", "--regex", "", "Search with a regular expression."}
+	sinceFlag            flag = flag{"", "--since", "DATE", "Only show tweets posted on or after DATE."}
+	untilFlag            flag = flag{"", "--until", "DATE", "Only show tweets posted before DATE."}
+	indexFlag            flag = flag{"", "--index", "", "Search the index of every tweet twtr has seen."}
+	unreadFlag           flag = flag{"", "--unread", "", "Only show tweets that you haven't seen yet."}
+	followFlag           flag = flag{"", "--follow", "NICK", "Follow the suggested source NICK."}
+	logFlag              flag = flag{"", "--log", "FILE", "Read the web server access log FILE."}
+	statsFlag            flag = flag{"", "--stats", "", "Show your followers as metadata fields for your feed."}
+	addrFlag             flag = flag{"", "--addr", "ADDRESS", "Listen on ADDRESS, e.g. localhost:8080."}
+	certFlag             flag = flag{"", "--cert", "FILE", "Serve over TLS with the certificate in FILE."}
+	keyFlag              flag = flag{"", "--key", "FILE", "Serve over TLS with the private key in FILE."}
+	accessLogFlag        flag = flag{"", "--access-log", "FILE", "Write an access log of each request to FILE."}
+	gistFlag             flag = flag{"", "--gist", "TOKEN_FILE", "Host your feed in a new GitHub gist."}
+	formatFlag           flag = flag{"", "--format", "FORMAT", "Export as FORMAT, one of atom, rss, or jsonfeed."}
+	timelineFlag         flag = flag{"", "--timeline", "", "Export your personal timeline instead of a source."}
+	draftFlag            flag = flag{"", "--draft", "", "Save the tweet as a draft instead of posting it."}
+	atFlag               flag = flag{"", "--at", "TIME", "Schedule the tweet to be posted at TIME."}
 )
@@ -27,6 +27,17 @@ Commands:
 	unfollow    Remove an existing source from your list.
 	tweet       Send out a message into the void.
 	view        View a source that you follow.
+	search      Search the tweets of the sources that you follow.
+	mark-read   Mark the tweets of the sources that you follow as read.
+	mentions    View the tweets that mention you.
+	discover    Discover new sources from the sources that you follow.
+	followers   View the sources that follow you.
+	serve       Host your feed with a built-in web server.
+	export      Export a feed or your timeline for feed readers.
+	import      Import your posts from a Mastodon or Twitter archive.
+	delete      Delete one of your tweets.
+	edit        Edit one of your tweets.
+	drafts      Manage your drafts and scheduled tweets.
 	config      Update your configuration.
 `
 
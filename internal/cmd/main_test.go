@@ -26,6 +26,17 @@ Commands:
 	unfollow    Remove an existing source from your list.
 	tweet       Send out a message into the void.
 	view        View a source that you follow.
+	search      Search the tweets of the sources that you follow.
+	mark-read   Mark the tweets of the sources that you follow as read.
+	mentions    View the tweets that mention you.
+	discover    Discover new sources from the sources that you follow.
+	followers   View the sources that follow you.
+	serve       Host your feed with a built-in web server.
+	export      Export a feed or your timeline for feed readers.
+	import      Import your posts from a Mastodon or Twitter archive.
+	delete      Delete one of your tweets.
+	edit        Edit one of your tweets.
+	drafts      Manage your drafts and scheduled tweets.
 	config      Update your configuration.
 `
 
@@ -139,6 +150,116 @@ func TestMain(t *testing.T) {
 			args:   []string{"view", "--help"},
 			stderr: viewCommand.help(&Context{Self: "twtr"}),
 		},
+
+		// search
+		{
+			args:   []string{"search", "-h"},
+			stderr: searchCommand.help(&Context{Self: "twtr"}),
+		},
+		{
+			args:   []string{"search", "--help"},
+			stderr: searchCommand.help(&Context{Self: "twtr"}),
+		},
+
+		// mark-read
+		{
+			args:   []string{"mark-read", "-h"},
+			stderr: markReadCommand.help(&Context{Self: "twtr"}),
+		},
+		{
+			args:   []string{"mark-read", "--help"},
+			stderr: markReadCommand.help(&Context{Self: "twtr"}),
+		},
+
+		// mentions
+		{
+			args:   []string{"mentions", "-h"},
+			stderr: mentionsCommand.help(&Context{Self: "twtr"}),
+		},
+		{
+			args:   []string{"mentions", "--help"},
+			stderr: mentionsCommand.help(&Context{Self: "twtr"}),
+		},
+
+		// discover
+		{
+			args:   []string{"discover", "-h"},
+			stderr: discoverCommand.help(&Context{Self: "twtr"}),
+		},
+		{
+			args:   []string{"discover", "--help"},
+			stderr: discoverCommand.help(&Context{Self: "twtr"}),
+		},
+
+		// followers
+		{
+			args:   []string{"followers", "-h"},
+			stderr: followersCommand.help(&Context{Self: "twtr"}),
+		},
+		{
+			args:   []string{"followers", "--help"},
+			stderr: followersCommand.help(&Context{Self: "twtr"}),
+		},
+
+		// serve
+		{
+			args:   []string{"serve", "-h"},
+			stderr: serveCommand.help(&Context{Self: "twtr"}),
+		},
+		{
+			args:   []string{"serve", "--help"},
+			stderr: serveCommand.help(&Context{Self: "twtr"}),
+		},
+
+		// export
+		{
+			args:   []string{"export", "-h"},
+			stderr: exportCommand.help(&Context{Self: "twtr"}),
+		},
+		{
+			args:   []string{"export", "--help"},
+			stderr: exportCommand.help(&Context{Self: "twtr"}),
+		},
+
+		// import
+		{
+			args:   []string{"import", "-h"},
+			stderr: importCommand.help(&Context{Self: "twtr"}),
+		},
+		{
+			args:   []string{"import", "--help"},
+			stderr: importCommand.help(&Context{Self: "twtr"}),
+		},
+
+		// delete
+		{
+			args:   []string{"delete", "-h"},
+			stderr: deleteCommand.help(&Context{Self: "twtr"}),
+		},
+		{
+			args:   []string{"delete", "--help"},
+			stderr: deleteCommand.help(&Context{Self: "twtr"}),
+		},
+
+		// edit
+		{
+			args:   []string{"edit", "-h"},
+			stderr: editCommand.help(&Context{Self: "twtr"}),
+		},
+		{
+			args:   []string{"edit", "--help"},
+			stderr: editCommand.help(&Context{Self: "twtr"}),
+		},
+
+		// drafts
+		{
+			args:   []string{"drafts", "-h"},
+			stderr: draftsCommand.help(&Context{Self: "twtr"}),
+		},
+		{
+			args:   []string{"drafts", "--help"},
+			stderr: draftsCommand.help(&Context{Self: "twtr"}),
+		},
 	}
 
 	for _, test := range tests {
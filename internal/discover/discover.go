@@ -0,0 +1,174 @@
+package discover
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"duriny.envs.sh/twtr/twtxt"
+)
+
+// Feed is a feed that you follow, along with its parsed twtxt file, the feeds
+// that it references are mined for suggestions.
+type Feed struct {
+	Nick string
+	URL  string
+	File *twtxt.File
+}
+
+// Suggestion is a feed that you don't follow yet, but that is referenced by
+// the feeds that you do follow.
+type Suggestion struct {
+	Nick      string
+	URL       string
+	Referrers []string  // the nicks of the feeds that reference this feed
+	Seen      time.Time // the most recent mention, if it was ever mentioned
+}
+
+// Source returns the Suggestion formatted as a SOURCE for the follow command.
+func (s *Suggestion) Source() string {
+	return s.Nick + " " + s.URL
+}
+
+// Discover mines the Feeds that you follow for the feeds that they reference,
+// through "# follow = nick url" metadata fields and mentions in their Tweets.
+// The following map, of nicks to urls, and your own url are left out.
+//
+// Suggestions are ranked by the number of Feeds that reference them, then by
+// how recently they were mentioned.
+func Discover(feeds []Feed, following map[string]string, self string) []*Suggestion {
+	skip := map[string]bool{normalize(self): true}
+	for _, u := range following {
+		skip[normalize(u)] = true
+	}
+
+	found := make(map[string]*candidate)
+
+	// reference is a helper to record that feed references the url.
+	reference := func(feed Feed, nick, u string, seen time.Time) {
+		key := normalize(u)
+		if !strings.Contains(key, "://") || skip[key] {
+			return
+		}
+
+		c := found[key]
+		if c == nil {
+			c = &candidate{
+				url:       u,
+				nicks:     make(map[string]int),
+				referrers: make(map[string]bool),
+			}
+
+			found[key] = c
+		}
+
+		if nick != "" {
+			c.nicks[nick]++
+		}
+
+		c.referrers[feed.Nick] = true
+
+		if seen.After(c.seen) {
+			c.seen = seen
+		}
+	}
+
+	for _, feed := range feeds {
+		if feed.File == nil {
+			continue
+		}
+
+		for _, field := range feed.File.Fields.Search("follow") {
+			nick, u := parseFollow(field.Value())
+			reference(feed, nick, u, time.Time{})
+		}
+
+		for _, tweet := range feed.File.Tweets {
+			for _, mention := range tweet.Mentions() {
+				reference(feed, mention.Nick(), mention.URL(), tweet.Time())
+			}
+		}
+	}
+
+	suggestions := make([]*Suggestion, 0, len(found))
+	for _, c := range found {
+		suggestions = append(suggestions, c.suggestion())
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+
+		if len(a.Referrers) != len(b.Referrers) {
+			return len(a.Referrers) > len(b.Referrers)
+		}
+
+		if !a.Seen.Equal(b.Seen) {
+			return a.Seen.After(b.Seen)
+		}
+
+		return a.URL < b.URL
+	})
+
+	return suggestions
+}
+
+// candidate is a helper to Discover(), it collects the references to a feed.
+type candidate struct {
+	url       string
+	nicks     map[string]int
+	referrers map[string]bool
+	seen      time.Time
+}
+
+// suggestion is a helper to Discover(), it picks the nick that the feed is
+// referenced by most often, or the host of its url if it has no nick.
+func (c *candidate) suggestion() *Suggestion {
+	s := &Suggestion{
+		URL:  c.url,
+		Seen: c.seen,
+	}
+
+	for nick, n := range c.nicks {
+		if n > c.nicks[s.Nick] || (n == c.nicks[s.Nick] && nick < s.Nick) {
+			s.Nick = nick
+		}
+	}
+
+	if s.Nick == "" {
+		if u, err := url.Parse(c.url); err == nil && u.Hostname() != "" {
+			s.Nick = u.Hostname()
+		} else {
+			s.Nick = c.url
+		}
+	}
+
+	for nick := range c.referrers {
+		s.Referrers = append(s.Referrers, nick)
+	}
+
+	sort.Strings(s.Referrers)
+
+	return s
+}
+
+// parseFollow is a helper to Discover(), it splits the value of a follow field
+// into a nick and url, the nick is optional.
+func parseFollow(value string) (nick, u string) {
+	fields := strings.Fields(value)
+
+	switch len(fields) {
+	case 1:
+		return "", fields[0]
+	case 2:
+		return fields[0], fields[1]
+	default:
+		return "", ""
+	}
+}
+
+// normalize is a helper to Discover(), it returns the url in a form that can
+// be compared, ignoring case and any trailing slash.
+func normalize(u string) string {
+	return strings.ToLower(strings.TrimSuffix(u, "/"))
+}
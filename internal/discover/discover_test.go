@@ -0,0 +1,95 @@
+package discover
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"duriny.envs.sh/twtr/twtxt"
+	"github.com/google/go-cmp/cmp"
+)
+
+// parse is a helper to create a twtxt file from its lines.
+func parse(t *testing.T, lines ...string) *twtxt.File {
+	t.Helper()
+
+	file, err := twtxt.Parse(strings.NewReader(strings.Join(lines, "\n")))
+	if err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	return file
+}
+
+func TestDiscover(t *testing.T) {
+	feeds := []Feed{
+		{
+			Nick: "alice",
+			URL:  "https://example.org/alice.txt",
+			File: parse(t,
+				"# nick = alice",
+				"# follow = bob https://example.org/bob.txt",
+				"# follow = carol https://example.org/carol.txt",
+				"# follow = https://example.org/dave.txt",
+				"# follow = not a feed",
+				"2022-03-01T09:00:00Z\t@<erin https://example.org/erin.txt> welcome!",
+				"2022-03-02T09:00:00Z\t@<me https://example.org/me.txt> hi!",
+			),
+		},
+		{
+			Nick: "bob",
+			URL:  "https://example.org/bob.txt",
+			File: parse(t,
+				"# follow = alice https://example.org/alice.txt",
+				"# follow = caz https://example.org/carol.txt/",
+				"2022-03-03T09:00:00Z\t@<https://example.org/erin.txt> and @<carol https://example.org/carol.txt> lunch?",
+				"2022-02-01T09:00:00Z\t@<frank https://example.org/frank.txt> hello",
+			),
+		},
+		{
+			Nick: "unreachable",
+			URL:  "https://example.org/unreachable.txt",
+		},
+	}
+
+	following := map[string]string{
+		"alice": "https://example.org/alice.txt",
+		"bob":   "https://example.org/bob.txt",
+	}
+
+	have := Discover(feeds, following, "https://example.org/me.txt")
+
+	want := []*Suggestion{
+		{
+			Nick:      "carol",
+			URL:       "https://example.org/carol.txt",
+			Referrers: []string{"alice", "bob"},
+			Seen:      time.Date(2022, 3, 3, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			Nick:      "erin",
+			URL:       "https://example.org/erin.txt",
+			Referrers: []string{"alice", "bob"},
+			Seen:      time.Date(2022, 3, 3, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			Nick:      "frank",
+			URL:       "https://example.org/frank.txt",
+			Referrers: []string{"bob"},
+			Seen:      time.Date(2022, 2, 1, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			Nick:      "example.org",
+			URL:       "https://example.org/dave.txt",
+			Referrers: []string{"alice"},
+		},
+	}
+
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Errorf("unexpected suggestions (-want +have):\n%s", diff)
+	}
+
+	if have, want := have[0].Source(), "carol https://example.org/carol.txt"; have != want {
+		t.Errorf("have source %q, want %q", have, want)
+	}
+}
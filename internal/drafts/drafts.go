@@ -0,0 +1,230 @@
+package drafts
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"duriny.envs.sh/twtr/internal/atomicfile"
+	"duriny.envs.sh/twtr/internal/twtfile"
+	"duriny.envs.sh/twtr/twtxt"
+)
+
+var (
+	// ErrNotFound is returned when there is no Draft with an ID.
+	ErrNotFound = errors.New("draft not found")
+
+	// ErrEmpty is returned when a Draft has no text.
+	ErrEmpty = errors.New("empty draft")
+)
+
+// Draft is a Tweet that hasn't been posted yet, a Draft that is scheduled is
+// posted by Flush once its time has come, stamped with the scheduled time.
+type Draft struct {
+	ID      int       `json:"id"`
+	Text    string    `json:"text"`
+	Created time.Time `json:"created"`
+	At      time.Time `json:"at"` // zero unless scheduled
+}
+
+// Scheduled reports if the Draft is scheduled to be posted.
+func (d *Draft) Scheduled() bool {
+	return !d.At.IsZero()
+}
+
+// Due reports if the Draft is scheduled to be posted at or before now.
+func (d *Draft) Due(now time.Time) bool {
+	return d.Scheduled() && !d.At.After(now)
+}
+
+// Store is where your Drafts are kept between runs, in a file next to the rest
+// of twtr's state. Each Draft is given an ID that is never reused, so that a
+// Draft can't be mixed up with one that was removed.
+type Store struct {
+	path   string
+	next   int
+	drafts []*Draft
+}
+
+// store is the file format of a Store.
+type store struct {
+	Next   int      `json:"next"`
+	Drafts []*Draft `json:"drafts"`
+}
+
+// Open reads the Store saved in the file at path, if there is no such file then
+// there are no Drafts yet.
+func Open(path string) (*Store, error) {
+	s := &Store{path: path, next: 1}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var saved store
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, err
+	}
+
+	s.drafts = saved.Drafts
+
+	if saved.Next > s.next {
+		s.next = saved.Next
+	}
+
+	return s, nil
+}
+
+// Save writes the Store to its file, see atomicfile.WriteFile.
+func (s *Store) Save() error {
+	data, err := json.MarshalIndent(store{Next: s.next, Drafts: s.drafts}, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	return atomicfile.WriteFile(s.path, data, 0o600)
+}
+
+// Add adds a new Draft of the text, scheduled at the given time, or not
+// scheduled if at is zero, and returns it.
+func (s *Store) Add(text string, at, now time.Time) (*Draft, error) {
+	if text = strings.TrimSpace(text); text == "" {
+		return nil, ErrEmpty
+	}
+
+	draft := &Draft{
+		ID:      s.next,
+		Text:    text,
+		Created: now,
+		At:      at,
+	}
+
+	s.next++
+	s.drafts = append(s.drafts, draft)
+
+	return draft, nil
+}
+
+// Get returns the Draft with the ID.
+func (s *Store) Get(id int) (*Draft, error) {
+	for _, draft := range s.drafts {
+		if draft.ID == id {
+			return draft, nil
+		}
+	}
+
+	return nil, ErrNotFound
+}
+
+// Edit replaces the text of the Draft with the ID.
+func (s *Store) Edit(id int, text string) error {
+	draft, err := s.Get(id)
+	if err != nil {
+		return err
+	}
+
+	if text = strings.TrimSpace(text); text == "" {
+		return ErrEmpty
+	}
+
+	draft.Text = text
+
+	return nil
+}
+
+// Remove removes the Draft with the ID.
+func (s *Store) Remove(id int) error {
+	for i, draft := range s.drafts {
+		if draft.ID == id {
+			s.drafts = append(s.drafts[:i:i], s.drafts[i+1:]...)
+			return nil
+		}
+	}
+
+	return ErrNotFound
+}
+
+// List returns the Drafts, the scheduled Drafts first in the order that they
+// will be posted, and then the other Drafts in the order they were created.
+func (s *Store) List() []*Draft {
+	drafts := make([]*Draft, len(s.drafts))
+	copy(drafts, s.drafts)
+
+	sort.SliceStable(drafts, func(i, j int) bool {
+		a, b := drafts[i], drafts[j]
+
+		switch {
+		case a.Scheduled() != b.Scheduled():
+			return a.Scheduled()
+		case a.Scheduled():
+			return a.At.Before(b.At)
+		default:
+			return a.Created.Before(b.Created)
+		}
+	})
+
+	return drafts
+}
+
+// Post posts the Draft with the ID to the twtfile at path now, whether or not
+// it is scheduled, and removes it from the Store. The Store is saved once the
+// Tweet is in the twtfile.
+func (s *Store) Post(path string, id int, now time.Time) (*twtxt.Tweet, error) {
+	draft, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	tweet := twtxt.NewTweetAt(draft.Text, now)
+
+	if _, err := twtfile.Merge(path, twtxt.Tweets{tweet}); err != nil {
+		return nil, err
+	}
+
+	s.Remove(id)
+
+	return tweet, s.Save()
+}
+
+// Flush posts every Draft that is due at now to the twtfile at path, each one
+// stamped with the time that it was scheduled at, and removes them from the
+// Store. Flush is meant to be run regularly, e.g. by cron, so a Draft that was
+// due before the last Tweet of the twtfile is still inserted in order.
+//
+// The Store is saved once the Tweets are in the twtfile, if saving it fails
+// then the Drafts are flushed again next time, but not posted twice.
+func (s *Store) Flush(path string, now time.Time) (twtxt.Tweets, error) {
+	tweets := make(twtxt.Tweets, 0)
+	kept := make([]*Draft, 0, len(s.drafts))
+
+	for _, draft := range s.drafts {
+		if draft.Due(now) {
+			tweets = append(tweets, twtxt.NewTweetAt(draft.Text, draft.At))
+		} else {
+			kept = append(kept, draft)
+		}
+	}
+
+	if len(tweets) == 0 {
+		return tweets, nil
+	}
+
+	if _, err := twtfile.Merge(path, tweets); err != nil {
+		return nil, err
+	}
+
+	s.drafts = kept
+
+	sort.Stable(tweets)
+
+	return tweets, s.Save()
+}
@@ -0,0 +1,145 @@
+package drafts
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestStore(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "drafts.json")
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	s, err := Open(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	if _, err := s.Add(" \n", time.Time{}, now); !errors.Is(err, ErrEmpty) {
+		t.Errorf("have error %v, want %v", err, ErrEmpty)
+	}
+
+	adds := []struct {
+		text string
+		at   time.Time
+	}{
+		{text: "work in progress"},
+		{text: "maintenance tomorrow", at: now.Add(24 * time.Hour)},
+		{text: "maintenance now", at: now.Add(time.Hour)},
+		{text: "typo"},
+	}
+
+	for i, add := range adds {
+		draft, err := s.Add(add.text, add.at, now.Add(time.Duration(i)*time.Minute))
+		if err != nil {
+			t.Fatalf("unexpected error: %q", err)
+		}
+
+		if have, want := draft.ID, i+1; have != want {
+			t.Errorf("have ID %d, want %d", have, want)
+		}
+	}
+
+	if err := s.Edit(1, "ready to go\n"); err != nil {
+		t.Errorf("unexpected error: %q", err)
+	}
+
+	if err := s.Remove(4); err != nil {
+		t.Errorf("unexpected error: %q", err)
+	}
+
+	if err := s.Remove(4); !errors.Is(err, ErrNotFound) {
+		t.Errorf("have error %v, want %v", err, ErrNotFound)
+	}
+
+	if err := s.Save(); err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	// the drafts and the next ID are kept between runs
+	s, err = Open(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	have := make([]string, 0)
+	for _, draft := range s.List() {
+		have = append(have, draft.Text)
+	}
+
+	want := []string{"maintenance now", "maintenance tomorrow", "ready to go"}
+
+	if !cmp.Equal(have, want) {
+		t.Errorf("diff:\n%s", cmp.Diff(have, want))
+	}
+
+	if draft, _ := s.Add("another", time.Time{}, now); draft.ID != 5 {
+		t.Errorf("have ID %d, want 5", draft.ID)
+	}
+
+	t.Run("Flush", func(t *testing.T) {
+		twtfile := filepath.Join(dir, "twtxt.txt")
+		feed := "2026-10-19T13:30:00Z\tposted after the schedule\n"
+
+		if err := os.WriteFile(twtfile, []byte(feed), 0o644); err != nil {
+			t.Fatalf("unexpected error: %q", err)
+		}
+
+		tweets, err := s.Flush(twtfile, now.Add(2*time.Hour))
+		if err != nil {
+			t.Fatalf("unexpected error: %q", err)
+		}
+
+		if have, want := len(tweets), 1; have != want {
+			t.Fatalf("have %d tweets, want %d", have, want)
+		}
+
+		data, _ := os.ReadFile(twtfile)
+		want := "2026-10-19T13:00:00Z\tmaintenance now\n" + feed
+
+		if have := string(data); have != want {
+			t.Errorf("have %q, want %q", have, want)
+		}
+
+		if _, err := s.Get(3); !errors.Is(err, ErrNotFound) {
+			t.Errorf("have error %v, want the flushed draft to be removed", err)
+		}
+
+		// nothing is due, so the twtfile isn't touched
+		if tweets, err := s.Flush(twtfile, now.Add(2*time.Hour)); err != nil || len(tweets) != 0 {
+			t.Errorf("have %d tweets and error %v, want none", len(tweets), err)
+		}
+	})
+
+	t.Run("Post", func(t *testing.T) {
+		twtfile := filepath.Join(dir, "post.txt")
+
+		tweet, err := s.Post(twtfile, 2, now)
+		if err != nil {
+			t.Fatalf("unexpected error: %q", err)
+		}
+
+		if have, want := tweet.String(), "2026-10-19T12:00:00Z\tmaintenance tomorrow"; have != want {
+			t.Errorf("have %q, want %q", have, want)
+		}
+
+		if _, err := s.Post(twtfile, 2, now); !errors.Is(err, ErrNotFound) {
+			t.Errorf("have error %v, want %v", err, ErrNotFound)
+		}
+
+		// the store is saved after posting
+		saved, err := Open(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %q", err)
+		}
+
+		if have, want := len(saved.List()), 2; have != want {
+			t.Errorf("have %d drafts, want %d", have, want)
+		}
+	})
+}
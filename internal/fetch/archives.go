@@ -0,0 +1,112 @@
+package fetch
+
+import (
+	"context"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"duriny.envs.sh/twtr/twtxt"
+)
+
+// maxArchives is the most archived feeds that are fetched for a feed, so that
+// archived feeds that refer to each other in a loop are not fetched forever.
+const maxArchives = 100
+
+// Archives fetches the archived feeds of the feed at the url, and returns their
+// Tweets, the file is the feed as it was fetched. A feed announces its newest
+// archived feed with a "prev" field, which has the twt hash of the last Tweet
+// in the archived feed and its url, relative to the feed:
+//
+//     # prev = 5vbi2ea twtxt-2022-03.txt
+//
+// Each archived feed may announce an older one of its own, they are fetched in
+// turn. Archived feeds of remote feeds have to be on the same server, and only
+// local feeds can have local archived feeds. The Tweets of the archived feeds
+// are added to the Index, if any, as Tweets of the feed and by its nick, as the
+// twt hashes of archived Tweets don't change.
+func (f *Fetcher) Archives(ctx context.Context, feed string, file *twtxt.File) (twtxt.Tweets, error) {
+	tweets := make(twtxt.Tweets, 0)
+	seen := map[string]bool{feed: true}
+	current, archived := feed, file
+
+	for i := 0; i < maxArchives; i++ {
+		archive := prev(current, archived, f.Base)
+		if archive == "" || seen[archive] {
+			break
+		}
+
+		seen[archive] = true
+
+		body, err := f.Get(ctx, archive)
+		if err != nil {
+			return nil, err
+		}
+
+		archived, err = parse(body)
+		if err != nil {
+			return nil, &Error{URL: archive, Err: err}
+		}
+
+		// archived feeds rarely have a nick, the nick of the feed is used
+		if f.Index != nil {
+			f.index(feed, file, archived.Tweets)
+		}
+
+		tweets = append(tweets, archived.Tweets...)
+		current = archive
+	}
+
+	return tweets, nil
+}
+
+// prev is a helper to Archives(), it returns the url of the archived feed that
+// the file of the feed announces, or an empty string if there is none, or if
+// the archived feed is not on the same server as the feed.
+func prev(feed string, file *twtxt.File, base string) string {
+	fields := file.Fields.Search("prev")
+	if len(fields) == 0 {
+		return ""
+	}
+
+	// the twt hash is optional, the url is always last
+	parts := strings.Fields(fields[0].Value())
+	if len(parts) == 0 {
+		return ""
+	}
+
+	ref := parts[len(parts)-1]
+
+	// archived feeds of local feeds are on disk too, relative to the feed
+	if path, ok, err := LocalPath(feed, base); ok {
+		if err != nil {
+			return ""
+		}
+
+		archive, local, err := LocalPath(ref, filepath.Dir(path))
+		if err != nil || !local {
+			return ""
+		}
+
+		return archive
+	}
+
+	u, err := url.Parse(feed)
+	if err != nil {
+		return ""
+	}
+
+	r, err := url.Parse(ref)
+	if err != nil {
+		return ""
+	}
+
+	// archived feeds of remote feeds have to be on the same server, so that a
+	// feed can't have local files or other hosts read
+	archive := u.ResolveReference(r)
+	if archive.Scheme != u.Scheme || archive.Host != u.Host {
+		return ""
+	}
+
+	return archive.String()
+}
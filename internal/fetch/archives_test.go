@@ -0,0 +1,147 @@
+package fetch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"duriny.envs.sh/twtr/internal/index"
+	"duriny.envs.sh/twtr/twtxt"
+)
+
+func TestArchives(t *testing.T) {
+	feeds := map[string]string{
+		"/twtxt.txt":                 "# nick = alice\n# prev = abcdefg archive/twtxt-2022-02.txt\n2022-03-01T09:00:00Z\tThird\n",
+		"/archive/twtxt-2022-02.txt": "# prev = twtxt-2022-01.txt\n2022-02-01T09:00:00Z\tSecond\n",
+		// an archived feed that refers back to a newer one is not fetched twice
+		"/archive/twtxt-2022-01.txt": "# prev = twtxt-2022-02.txt\n2022-01-01T09:00:00Z\tFirst\n",
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		feed, ok := feeds[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Write([]byte(feed))
+	}))
+
+	defer ts.Close()
+
+	dir := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(dir, "twtxt.txt"), []byte(feeds["/twtxt.txt"]), 0o644); err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, "archive"), 0o755); err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	for _, name := range []string{"twtxt-2022-02.txt", "twtxt-2022-01.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, "archive", name), []byte(feeds["/archive/"+name]), 0o644); err != nil {
+			t.Fatalf("unexpected error: %q", err)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		feed  string
+		nicks map[string]string
+		nick  string
+	}{
+		{name: "Remote", feed: ts.URL + "/twtxt.txt", nick: "alice"},
+		{name: "Local", feed: filepath.Join(dir, "twtxt.txt"), nick: "alice"},
+		{name: "Followed", feed: ts.URL + "/twtxt.txt", nicks: map[string]string{ts.URL + "/twtxt.txt": "al"}, nick: "al"},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			idx := index.New(filepath.Join(t.TempDir(), "index"))
+			fetcher := &Fetcher{Index: idx, Nicks: test.nicks}
+
+			file, err := fetcher.Fetch(context.Background(), test.feed)
+			if err != nil {
+				t.Fatalf("unexpected error: %q", err)
+			}
+
+			tweets, err := fetcher.Archives(context.Background(), test.feed, file)
+			if err != nil {
+				t.Fatalf("unexpected error: %q", err)
+			}
+
+			if have, want := texts(tweets), "Second, First"; have != want {
+				t.Errorf("have %q, want %q", have, want)
+			}
+
+			// archived Tweets are indexed as Tweets of the feed, by its nick
+			hash := tweets[0].Hash(test.feed)
+			if doc := idx.Get(hash); doc == nil || doc.URL != test.feed || doc.Nick != test.nick {
+				t.Errorf("have %v indexed for %s, want a Tweet of %s by %s", doc, hash, test.feed, test.nick)
+			}
+		})
+	}
+}
+
+func TestArchivesMissing(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	defer ts.Close()
+
+	file, err := twtxt.Parse(strings.NewReader("# prev = abcdefg twtxt-2022-02.txt\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	if _, err := (&Fetcher{}).Archives(context.Background(), ts.URL+"/twtxt.txt", file); err == nil {
+		t.Error("want an error for a missing archived feed")
+	}
+}
+
+// texts is a helper to join the texts of the Tweets for the tests.
+func texts(tweets twtxt.Tweets) string {
+	var all []string
+	for _, tweet := range tweets {
+		all = append(all, tweet.Text())
+	}
+
+	return strings.Join(all, ", ")
+}
+
+func TestArchivesPrev(t *testing.T) {
+	tests := []struct {
+		feed string
+		prev string
+		want string
+	}{
+		{feed: "https://example.org/alice/twtxt.txt", prev: "abcdefg twtxt-2022-02.txt", want: "https://example.org/alice/twtxt-2022-02.txt"},
+		{feed: "https://example.org/alice/twtxt.txt", prev: "/archive/alice.txt", want: "https://example.org/archive/alice.txt"},
+		{feed: "https://example.org/alice/twtxt.txt", prev: "abcdefg https://example.org/alice/old.txt", want: "https://example.org/alice/old.txt"},
+		{feed: "https://example.org/alice/twtxt.txt", prev: "abcdefg file:///home/me/secret.txt"},
+		{feed: "https://example.org/alice/twtxt.txt", prev: "abcdefg gopher://internal:70/0/twtxt.txt"},
+		{feed: "https://example.org/alice/twtxt.txt", prev: "abcdefg http://example.org/alice/old.txt"},
+		{feed: "https://example.org/alice/twtxt.txt", prev: "abcdefg https://example.com/alice/old.txt"},
+		{feed: "https://example.org/alice/twtxt.txt", prev: "abcdefg //example.com/alice/old.txt"},
+		{feed: "/home/alice/twtxt.txt", prev: "abcdefg archive/twtxt-2022-02.txt", want: "/home/alice/archive/twtxt-2022-02.txt"},
+		{feed: "/home/alice/twtxt.txt", prev: "abcdefg file:///home/alice/old.txt", want: "/home/alice/old.txt"},
+		{feed: "/home/alice/twtxt.txt", prev: "abcdefg https://example.org/alice/old.txt"},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.prev, func(t *testing.T) {
+			file := &twtxt.File{Fields: twtxt.Fields{twtxt.NewField("prev", test.prev)}}
+
+			if have := prev(test.feed, file, ""); have != test.want {
+				t.Errorf("have %q, want %q", have, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package fetch
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"time"
+
+	"duriny.envs.sh/twtr/internal/atomicfile"
+)
+
+// Cache keeps the feeds that have been fetched, so that they are only fetched
+// again once they are out of date. Feeds are cached by their url, whatever the
+// protocol that they were fetched with.
+type Cache struct {
+	Dir string
+
+	// MaxAge is the time until a cached feed is out of date.
+	MaxAge time.Duration
+}
+
+// Get returns the cached contents of the feed at the url, reporting false if
+// the feed is not cached or is out of date by now.
+func (c *Cache) Get(url string, now time.Time) ([]byte, bool) {
+	path := c.path(url)
+
+	info, err := os.Stat(path)
+	if err != nil || now.Sub(info.ModTime()) >= c.MaxAge {
+		return nil, false
+	}
+
+	body, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+
+	return body, true
+}
+
+// GetSince returns the cached contents of the feed at the url, reporting false
+// if the feed is not cached or was cached before the feed was modified. It is
+// used for local feeds, whose modification time is known without fetching.
+func (c *Cache) GetSince(url string, modified time.Time) ([]byte, bool) {
+	path := c.path(url)
+
+	info, err := os.Stat(path)
+	if err != nil || info.ModTime().Before(modified) {
+		return nil, false
+	}
+
+	body, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+
+	return body, true
+}
+
+// Put caches the contents of the feed at the url.
+func (c *Cache) Put(url string, body []byte) error {
+	return atomicfile.WriteFile(c.path(url), body, 0o600)
+}
+
+// path is a helper to Get() and Put(), it returns the path of the cached feed,
+// named by the hash of its url so that any url is a valid file name.
+func (c *Cache) path(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(c.Dir, hex.EncodeToString(sum[:]))
+}
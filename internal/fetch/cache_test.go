@@ -0,0 +1,65 @@
+package fetch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCache(t *testing.T) {
+	cache := &Cache{
+		Dir:    filepath.Join(t.TempDir(), "cache"),
+		MaxAge: time.Minute,
+	}
+
+	if _, ok := cache.Get("https://example.org/twtxt.txt", time.Now()); ok {
+		t.Error("have a cached feed before any were cached")
+	}
+
+	if err := cache.Put("https://example.org/twtxt.txt", []byte("Fiat lux!")); err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	if body, ok := cache.Get("https://example.org/twtxt.txt", time.Now()); !ok || string(body) != "Fiat lux!" {
+		t.Errorf("have cached %q, %t, want %q", body, ok, "Fiat lux!")
+	}
+
+	if _, ok := cache.Get("gopher://example.org/0/twtxt.txt", time.Now()); ok {
+		t.Error("have a cached feed for a different url")
+	}
+
+	if _, ok := cache.Get("https://example.org/twtxt.txt", time.Now().Add(time.Minute)); ok {
+		t.Error("have a cached feed that is out of date")
+	}
+}
+
+func TestFetchCache(t *testing.T) {
+	var requests int
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte("2016-02-04T13:30:00+01:00\tFiat lux!\n"))
+	}))
+
+	defer ts.Close()
+
+	fetcher := &Fetcher{
+		Cache: &Cache{
+			Dir:    t.TempDir(),
+			MaxAge: time.Minute,
+		},
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := fetcher.Fetch(context.Background(), ts.URL+"/twtxt.txt"); err != nil {
+			t.Fatalf("unexpected error: %q", err)
+		}
+	}
+
+	if requests != 1 {
+		t.Errorf("have %d requests, want 1", requests)
+	}
+}
@@ -0,0 +1,239 @@
+package fetch
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"duriny.envs.sh/twtr/internal/index"
+	"duriny.envs.sh/twtr/internal/timeline"
+	"duriny.envs.sh/twtr/twtxt"
+)
+
+// Error is returned when a feed could not be fetched, it wraps the error of the
+// protocol that the feed was fetched with.
+type Error struct {
+	URL string
+	Err error
+}
+
+func (e *Error) Error() string { return "fetch " + e.URL + ": " + e.Err.Error() }
+func (e *Error) Unwrap() error { return e.Err }
+
+// Fetcher fetches the feeds that you follow, the protocol is chosen by the
+// scheme of each feed's url.
+type Fetcher struct {
+	// Timeout is the maximum time that fetching a single feed may take, zero
+	// means no timeout.
+	Timeout time.Duration
+
+	// UserAgent is sent to the servers that support it.
+	UserAgent string
+
+	// Dir is the directory for the state kept between runs, such as the
+	// pinned certificates of Gemini servers. An empty Dir means the directory
+	// of the Cache, or else twtr's directory in the user's cache directory.
+	Dir string
+
+	// Base is the directory that local feeds with relative paths are relative
+	// to, an empty Base means the working directory.
+	Base string
+
+	// HTTP is the client for http and https feeds, nil means the default.
+	HTTP *HTTP
+
+	// Gemini is the client for gemini feeds, nil means the default.
+	Gemini *Gemini
+
+	// Gopher is the client for gopher feeds, nil means the default.
+	Gopher *Gopher
+
+	// Cache keeps the fetched feeds until they are out of date, nil means
+	// feeds are always fetched.
+	Cache *Cache
+
+	// Index is updated with the Tweets of each feed as it is fetched, nil
+	// means nothing is indexed. Saving the Index is up to the caller.
+	Index *index.Index
+
+	// Nicks are the nicks that you follow the feeds by, keyed by their url.
+	// The Tweets of a feed are indexed by its nick, or else by the nick that
+	// the feed gives itself.
+	Nicks map[string]string
+
+	mu sync.Mutex // guards Index, and the clients as they are created
+}
+
+// Fetch fetches the feed at the url, and parses it. RSS, Atom, and JSON Feeds
+// are detected and converted into twtxt, see Convert, so they can be followed
+// like any other feed. The Tweets of the feed are added to the Index, if any.
+func (f *Fetcher) Fetch(ctx context.Context, feed string) (*twtxt.File, error) {
+	body, err := f.Get(ctx, feed)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := parse(body)
+	if err != nil {
+		return nil, &Error{URL: feed, Err: err}
+	}
+
+	if f.Index != nil {
+		f.index(feed, file, file.Tweets)
+	}
+
+	return file, nil
+}
+
+// parse is a helper to Fetch() and Archives(), it parses the body of a feed,
+// converting syndication feeds into twtxt.
+func parse(body []byte) (*twtxt.File, error) {
+	if format := Detect(body); format != "" {
+		return Convert(body, format)
+	}
+
+	return twtxt.Parse(bytes.NewReader(body))
+}
+
+// index is a helper to Fetch() and Archives(), it adds the Tweets of the feed
+// to the Index, by the nick that the feed is followed by, or else the nick that
+// the file of the feed gives itself, if any.
+func (f *Fetcher) index(feed string, file *twtxt.File, tweets twtxt.Tweets) {
+	nick, ok := f.Nicks[feed]
+	if fields := file.Fields.Search("nick"); !ok && len(fields) > 0 {
+		nick = fields[0].Value()
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.Index.Add(timeline.New(nick, feed, tweets))
+}
+
+// Get fetches the raw contents of the feed at the url, or returns its cached
+// contents if they are not out of date. Failing to cache a feed is not an
+// error, the feed is fetched again next time.
+//
+// Local feeds, see LocalPath, are read from disk, and are only out of date in
+// the cache once they have been modified.
+func (f *Fetcher) Get(ctx context.Context, feed string) ([]byte, error) {
+	if path, ok, err := LocalPath(feed, f.Base); ok {
+		if err != nil {
+			return nil, &Error{URL: feed, Err: err}
+		}
+
+		body, err := f.readLocal(feed, path)
+		if err != nil {
+			return nil, &Error{URL: feed, Err: err}
+		}
+
+		return body, nil
+	}
+
+	u, err := url.Parse(feed)
+	if err != nil {
+		return nil, &Error{URL: feed, Err: err}
+	}
+
+	if f.Cache != nil {
+		if body, ok := f.Cache.Get(feed, time.Now()); ok {
+			return body, nil
+		}
+	}
+
+	if f.Timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, f.Timeout)
+		defer cancel()
+	}
+
+	var body []byte
+
+	switch u.Scheme {
+	case "http", "https":
+		body, err = f.http().Get(ctx, u)
+	case "gemini":
+		body, err = f.gemini().Get(ctx, u)
+	case "gopher":
+		body, err = f.gopher().Get(ctx, u)
+	default:
+		err = fmt.Errorf("unsupported scheme: %q", u.Scheme)
+	}
+
+	if err != nil {
+		return nil, &Error{URL: feed, Err: err}
+	}
+
+	if f.Cache != nil {
+		f.Cache.Put(feed, body)
+	}
+
+	return body, nil
+}
+
+// http is a helper to Get(), it returns the client for http and https feeds.
+func (f *Fetcher) http() *HTTP {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.HTTP == nil {
+		f.HTTP = &HTTP{UserAgent: f.UserAgent}
+	}
+
+	return f.HTTP
+}
+
+// gemini is a helper to Get(), it returns the client for gemini feeds, with its
+// certificate pins kept in the state directory, see dir().
+func (f *Fetcher) gemini() *Gemini {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.Gemini == nil {
+		f.Gemini = &Gemini{}
+
+		if dir := f.dir(); dir != "" {
+			f.Gemini.Pins = NewPins(filepath.Join(dir, "gemini_known_hosts"))
+		} else {
+			f.Gemini.Pins = NewPins("")
+		}
+	}
+
+	return f.Gemini
+}
+
+// dir is a helper to gemini(), it returns the directory for the state kept
+// between runs, which is only empty if there is no cache directory at all.
+func (f *Fetcher) dir() string {
+	switch {
+	case f.Dir != "":
+		return f.Dir
+	case f.Cache != nil && f.Cache.Dir != "":
+		return f.Cache.Dir
+	}
+
+	cache, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(cache, "twtr")
+}
+
+// gopher is a helper to Get(), it returns the client for gopher feeds.
+func (f *Fetcher) gopher() *Gopher {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.Gopher == nil {
+		f.Gopher = &Gopher{}
+	}
+
+	return f.Gopher
+}
@@ -0,0 +1,173 @@
+package fetch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"duriny.envs.sh/twtr/internal/index"
+)
+
+func TestFetch(t *testing.T) {
+	feed := "# nick = alice\n2016-02-04T13:30:00+01:00\tFiat lux!\n"
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/twtxt.txt":
+			if r.UserAgent() != "twtr/v0.0.0" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+
+			w.Write([]byte(feed))
+		case "/atom.xml":
+			w.Write([]byte(`<feed xmlns="http://www.w3.org/2005/Atom"><entry><title>Fiat lux!</title><published>2016-02-04T13:30:00+01:00</published></entry></feed>`))
+		case "/slow.txt":
+			time.Sleep(100 * time.Millisecond)
+			w.Write([]byte(feed))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	defer ts.Close()
+
+	tests := []struct {
+		name string
+		url  string
+		err  bool
+	}{
+		{
+			name: "HTTP",
+			url:  ts.URL + "/twtxt.txt",
+		},
+		{
+			name: "Atom",
+			url:  ts.URL + "/atom.xml",
+		},
+		{
+			name: "NotFound",
+			url:  ts.URL + "/missing.txt",
+			err:  true,
+		},
+		{
+			name: "Timeout",
+			url:  ts.URL + "/slow.txt",
+			err:  true,
+		},
+		{
+			name: "UnsupportedScheme",
+			url:  "ftp://example.org/twtxt.txt",
+			err:  true,
+		},
+		{
+			name: "InvalidURL",
+			url:  "http://example.org/%zz",
+			err:  true,
+		},
+	}
+
+	fetcher := &Fetcher{
+		Timeout:   50 * time.Millisecond,
+		UserAgent: "twtr/v0.0.0",
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			file, err := fetcher.Fetch(context.Background(), test.url)
+
+			if (err != nil) != test.err {
+				t.Fatalf("err = %v, want error %t", err, test.err)
+			}
+
+			if err != nil {
+				var fetchErr *Error
+				if !errors.As(err, &fetchErr) || fetchErr.URL != test.url {
+					t.Errorf("have error %v, want an Error for %s", err, test.url)
+				}
+
+				return
+			}
+
+			if have := len(file.Tweets); have != 1 {
+				t.Errorf("have %d tweets, want 1", have)
+			}
+		})
+	}
+}
+
+func TestFetchIndex(t *testing.T) {
+	feed := "# nick = alice\n2016-02-04T13:30:00+01:00\tFiat lux!\n"
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(feed))
+	}))
+
+	defer ts.Close()
+
+	idx := index.New(filepath.Join(t.TempDir(), "index"))
+	fetcher := &Fetcher{Index: idx}
+
+	if _, err := fetcher.Fetch(context.Background(), ts.URL+"/twtxt.txt"); err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	// a new Tweet is added once the feed is fetched again
+	feed += "2016-02-05T09:00:00+01:00\tHello again!\n"
+
+	if _, err := fetcher.Fetch(context.Background(), ts.URL+"/twtxt.txt"); err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	if have := idx.Len(); have != 2 {
+		t.Fatalf("have %d Tweets in the index, want 2", have)
+	}
+
+	docs, err := idx.Search("from:alice hello")
+	if err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	if len(docs) != 1 || docs[0].URL != ts.URL+"/twtxt.txt" {
+		t.Errorf("have %d results for from:alice hello, want 1", len(docs))
+	}
+}
+
+func TestFetchConcurrent(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("# nick = alice\n2016-02-04T13:30:00+01:00\tFiat lux!\n"))
+	}))
+
+	defer ts.Close()
+
+	fetcher := &Fetcher{Index: index.New(filepath.Join(t.TempDir(), "index"))}
+
+	var wg sync.WaitGroup
+
+	// the clients are created by whichever Fetch needs them first
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			if _, err := fetcher.Fetch(context.Background(), fmt.Sprintf("%s/%d.txt", ts.URL, i)); err != nil {
+				t.Errorf("unexpected error: %q", err)
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	if have := fetcher.Index.Len(); have != 8 {
+		t.Errorf("have %d Tweets in the index, want 8", have)
+	}
+}
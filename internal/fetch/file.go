@@ -0,0 +1,81 @@
+package fetch
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LocalPath returns the path of a feed that is read straight from disk, either
+// a file url, e.g. file:///home/alice/twtxt.txt, a path in the home directory,
+// e.g. ~/twtxt.txt, or any other path. Relative paths are relative to base,
+// or the working directory if base is empty. Reports false for any other url.
+func LocalPath(feed, base string) (string, bool, error) {
+	switch {
+	case strings.HasPrefix(feed, "file:"):
+		u, err := url.Parse(feed)
+		if err != nil {
+			return "", true, err
+		}
+
+		if u.Host != "" && u.Host != "localhost" {
+			return "", true, fmt.Errorf("file url on another host: %q", u.Host)
+		}
+
+		if u.Path == "" {
+			return "", true, errors.New("file url without a path")
+		}
+
+		return filepath.FromSlash(u.Path), true, nil
+
+	case strings.Contains(feed, "://"):
+		return "", false, nil
+
+	case feed == "~" || strings.HasPrefix(feed, "~/"):
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", true, err
+		}
+
+		return filepath.Join(home, filepath.FromSlash(feed[1:])), true, nil
+
+	case filepath.IsAbs(feed) || base == "":
+		return filepath.Clean(filepath.FromSlash(feed)), true, nil
+
+	default:
+		return filepath.Join(base, filepath.FromSlash(feed)), true, nil
+	}
+}
+
+// readLocal is a helper to Fetcher.Get(), it reads a local feed from disk, or
+// from the cache if the feed has not been modified since it was cached.
+func (f *Fetcher) readLocal(feed, path string) ([]byte, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.IsDir() {
+		return nil, fmt.Errorf("%s is a directory", path)
+	}
+
+	if f.Cache != nil {
+		if body, ok := f.Cache.GetSince(feed, info.ModTime()); ok {
+			return body, nil
+		}
+	}
+
+	body, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if f.Cache != nil {
+		f.Cache.Put(feed, body)
+	}
+
+	return body, nil
+}
@@ -0,0 +1,132 @@
+package fetch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLocalPath(t *testing.T) {
+	t.Setenv("HOME", "/home/alice")
+
+	tests := []struct {
+		feed  string
+		base  string
+		path  string
+		local bool
+		err   bool
+	}{
+		{feed: "file:///home/bob/public_html/twtxt.txt", path: "/home/bob/public_html/twtxt.txt", local: true},
+		{feed: "file://localhost/home/bob/twtxt.txt", path: "/home/bob/twtxt.txt", local: true},
+		{feed: "file://example.org/home/bob/twtxt.txt", local: true, err: true},
+		{feed: "file:", local: true, err: true},
+		{feed: "~/twtxt.txt", path: "/home/alice/twtxt.txt", local: true},
+		{feed: "~", path: "/home/alice", local: true},
+		{feed: "/home/carol/public_html/twtxt.txt", base: "/etc", path: "/home/carol/public_html/twtxt.txt", local: true},
+		{feed: "../bob/twtxt.txt", base: "/home/alice/.config/twtxt", path: "/home/alice/.config/bob/twtxt.txt", local: true},
+		{feed: "twtxt.txt", path: "twtxt.txt", local: true},
+		{feed: "~bob/twtxt.txt", path: "~bob/twtxt.txt", local: true},
+		{feed: "https://example.org/twtxt.txt"},
+		{feed: "gemini://example.org/twtxt.txt"},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.feed, func(t *testing.T) {
+			path, local, err := LocalPath(test.feed, test.base)
+
+			if (err != nil) != test.err {
+				t.Fatalf("err = %v, want error %t", err, test.err)
+			}
+
+			if local != test.local {
+				t.Errorf("local = %t, want %t", local, test.local)
+			}
+
+			if path != test.path {
+				t.Errorf("have path %q, want %q", path, test.path)
+			}
+		})
+	}
+}
+
+func TestFetchLocal(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "public_html", "twtxt.txt")
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	// write is a helper to write the feed with the given modification time.
+	write := func(feed string, modified time.Time) {
+		t.Helper()
+
+		if err := os.WriteFile(path, []byte(feed), 0o644); err != nil {
+			t.Fatalf("unexpected error: %q", err)
+		}
+
+		if err := os.Chtimes(path, modified, modified); err != nil {
+			t.Fatalf("unexpected error: %q", err)
+		}
+	}
+
+	now := time.Now()
+	write("2016-02-04T13:30:00+01:00\tFiat lux!\n", now.Add(-time.Hour))
+
+	fetcher := &Fetcher{
+		Base: dir,
+		Cache: &Cache{
+			Dir: filepath.Join(dir, "cache"),
+
+			// local feeds are cached until they are modified, however old
+			MaxAge: time.Nanosecond,
+		},
+	}
+
+	for _, feed := range []string{"file://" + filepath.ToSlash(path), "public_html/twtxt.txt"} {
+		file, err := fetcher.Fetch(context.Background(), feed)
+		if err != nil {
+			t.Fatalf("unexpected error: %q", err)
+		}
+
+		if have := len(file.Tweets); have != 1 {
+			t.Errorf("%s: have %d tweets, want 1", feed, have)
+		}
+	}
+
+	// a change that happened before the feed was cached is not seen
+	write("2016-02-04T13:30:00+01:00\tFiat lux!\n2016-02-05T13:30:00+01:00\tAgain!\n", now.Add(-time.Hour))
+
+	file, err := fetcher.Fetch(context.Background(), "public_html/twtxt.txt")
+	if err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	if have := len(file.Tweets); have != 1 {
+		t.Errorf("have %d tweets from the cache, want 1", have)
+	}
+
+	// a modified feed is read again
+	write("2016-02-04T13:30:00+01:00\tFiat lux!\n2016-02-05T13:30:00+01:00\tAgain!\n", now.Add(time.Hour))
+
+	file, err = fetcher.Fetch(context.Background(), "public_html/twtxt.txt")
+	if err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	if have := len(file.Tweets); have != 2 {
+		t.Errorf("have %d tweets after modifying the feed, want 2", have)
+	}
+
+	if _, err := fetcher.Fetch(context.Background(), "public_html/missing.txt"); err == nil {
+		t.Error("missing error for a missing feed")
+	}
+
+	if _, err := fetcher.Fetch(context.Background(), "public_html"); err == nil {
+		t.Error("missing error for a directory")
+	}
+}
@@ -0,0 +1,203 @@
+package fetch
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Gemini fetches feeds over the Gemini protocol, servers are trusted on first
+// use, their certificates are pinned rather than verified by a certificate
+// authority, as most Gemini servers use self-signed certificates.
+//
+// See https://gemini.circumlunar.space/docs/specification.gmi for more
+// information.
+type Gemini struct {
+	Pins *Pins
+
+	// MaxRedirects is the number of redirects that are followed, zero means
+	// the default of 5.
+	MaxRedirects int
+}
+
+// StatusError is returned for a Gemini response that is not a success or a
+// redirect, Meta is the error message from the server.
+type StatusError struct {
+	Status int
+	Meta   string
+}
+
+func (e *StatusError) Error() string {
+	var kind string
+
+	switch e.Status / 10 {
+	case 1:
+		kind = "input required"
+	case 4:
+		kind = "temporary failure"
+	case 5:
+		kind = "permanent failure"
+	case 6:
+		kind = "client certificate required"
+	default:
+		kind = "invalid status"
+	}
+
+	if e.Meta == "" {
+		return fmt.Sprintf("gemini: %d %s", e.Status, kind)
+	}
+
+	return fmt.Sprintf("gemini: %d %s: %s", e.Status, kind, e.Meta)
+}
+
+// ErrTooManyRedirects is returned when a feed redirects more than MaxRedirects
+// times.
+var ErrTooManyRedirects = errors.New("gemini: too many redirects")
+
+// Get fetches the contents of the feed at the url, following redirects.
+func (g *Gemini) Get(ctx context.Context, u *url.URL) ([]byte, error) {
+	max := g.MaxRedirects
+	if max == 0 {
+		max = 5
+	}
+
+	for redirects := 0; ; redirects++ {
+		status, meta, body, err := g.request(ctx, u)
+		if err != nil {
+			return nil, err
+		}
+
+		switch status / 10 {
+		case 2:
+			if meta != "" && !strings.HasPrefix(meta, "text/") {
+				return nil, fmt.Errorf("gemini: unexpected content type: %q", meta)
+			}
+
+			return body, nil
+		case 3:
+			if redirects >= max {
+				return nil, ErrTooManyRedirects
+			}
+
+			next, err := u.Parse(meta)
+			if err != nil {
+				return nil, fmt.Errorf("gemini: invalid redirect: %w", err)
+			}
+
+			if next.Scheme != "gemini" {
+				return nil, fmt.Errorf("gemini: redirect to unsupported scheme: %q", next.Scheme)
+			}
+
+			u = next
+		default:
+			return nil, &StatusError{Status: status, Meta: meta}
+		}
+	}
+}
+
+// maxHeader is the longest allowed response header, a two digit status, a
+// space, a meta of up to 1024 bytes, and CRLF.
+const maxHeader = 2 + 1 + 1024 + 2
+
+// request is a helper to Get(), it makes a single request, returning the status
+// and meta of the response header, and the body of a successful response.
+func (g *Gemini) request(ctx context.Context, u *url.URL) (int, string, []byte, error) {
+	if u.Host == "" {
+		return 0, "", nil, errors.New("gemini: missing host")
+	}
+
+	host := u.Host
+	if u.Port() == "" {
+		host = net.JoinHostPort(u.Hostname(), "1965")
+	}
+
+	dialer := &tls.Dialer{
+		Config: &tls.Config{
+			MinVersion: tls.VersionTLS12,
+			ServerName: u.Hostname(),
+
+			// the certificate is verified by its pin instead
+			InsecureSkipVerify: true,
+			VerifyConnection: func(cs tls.ConnectionState) error {
+				if len(cs.PeerCertificates) == 0 {
+					return errors.New("gemini: missing server certificate")
+				}
+
+				return g.Pins.Verify(host, cs.PeerCertificates[0], time.Now())
+			},
+		},
+	}
+
+	conn, err := dialer.DialContext(ctx, "tcp", host)
+	if err != nil {
+		return 0, "", nil, err
+	}
+
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	if _, err := io.WriteString(conn, u.String()+"\r\n"); err != nil {
+		return 0, "", nil, err
+	}
+
+	br := bufio.NewReader(conn)
+
+	header, err := br.ReadString('\n')
+	if err != nil && (err != io.EOF || header == "") {
+		return 0, "", nil, fmt.Errorf("gemini: reading header: %w", err)
+	}
+
+	if len(header) > maxHeader {
+		return 0, "", nil, errors.New("gemini: header too long")
+	}
+
+	status, meta, err := parseHeader(header)
+	if err != nil {
+		return 0, "", nil, err
+	}
+
+	if status/10 != 2 {
+		return status, meta, nil, nil
+	}
+
+	body, err := io.ReadAll(br)
+	if err != nil {
+		return 0, "", nil, err
+	}
+
+	return status, meta, body, nil
+}
+
+// parseHeader is a helper to request(), it parses the response header.
+//
+//     <STATUS><SPACE><META><CR><LF>
+func parseHeader(header string) (int, string, error) {
+	header = strings.TrimSuffix(strings.TrimSuffix(header, "\n"), "\r")
+
+	if len(header) < 2 || (len(header) > 2 && header[2] != ' ') {
+		return 0, "", fmt.Errorf("gemini: invalid header: %q", header)
+	}
+
+	status, err := strconv.Atoi(header[:2])
+	if err != nil || status < 10 {
+		return 0, "", fmt.Errorf("gemini: invalid header: %q", header)
+	}
+
+	var meta string
+	if len(header) > 2 {
+		meta = strings.TrimSpace(header[3:])
+	}
+
+	return status, meta, nil
+}
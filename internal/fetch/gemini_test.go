@@ -0,0 +1,264 @@
+package fetch
+
+import (
+	"bufio"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"net"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// certificate is a helper to create a self-signed certificate.
+func certificate(t *testing.T, notAfter time.Time) tls.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}
+}
+
+// geminiServer is an in-process Gemini server, responses maps the path of each
+// request to the raw response.
+type geminiServer struct {
+	mu        sync.Mutex
+	cert      tls.Certificate
+	responses map[string]string
+	listener  net.Listener
+}
+
+// serve is a helper to start a geminiServer, it is stopped when the test ends.
+func serve(t *testing.T, cert tls.Certificate, responses map[string]string) *geminiServer {
+	t.Helper()
+
+	s := &geminiServer{cert: cert, responses: responses}
+
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+
+			return &s.cert, nil
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	s.listener = listener
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go s.handle(conn)
+		}
+	}()
+
+	return s
+}
+
+func (s *geminiServer) handle(conn net.Conn) {
+	defer conn.Close()
+
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		return
+	}
+
+	u, err := url.Parse(strings.TrimSpace(line))
+	if err != nil {
+		conn.Write([]byte("59 bad request\r\n"))
+		return
+	}
+
+	resp, ok := s.responses[u.Path]
+	if !ok {
+		resp = "51 not found\r\n"
+	}
+
+	conn.Write([]byte(resp))
+}
+
+// url is a helper to return the gemini url of the path on the server.
+func (s *geminiServer) url(path string) string {
+	return "gemini://" + s.listener.Addr().String() + path
+}
+
+func TestGemini(t *testing.T) {
+	feed := "# nick = alice\n2016-02-04T13:30:00+01:00\tFiat lux!\n"
+
+	server := serve(t, certificate(t, time.Now().Add(time.Hour)), map[string]string{
+		"/twtxt.txt":   "20 text/plain; charset=utf-8\r\n" + feed,
+		"/moved.txt":   "31 /twtxt.txt\r\n",
+		"/temporary":   "30 twtxt.txt\r\n",
+		"/loop":        "31 /loop\r\n",
+		"/https":       "31 https://example.org/twtxt.txt\r\n",
+		"/slow":        "44 60\r\n",
+		"/gone":        "52 this feed has moved on\r\n",
+		"/search":      "10 search terms\r\n",
+		"/image.png":   "20 image/png\r\n\x89PNG",
+		"/no-meta":     "20\r\n" + feed,
+		"/bad-header":  "OK\r\n",
+		"/client-cert": "60 certificate required\r\n",
+	})
+
+	tests := []struct {
+		path   string
+		body   string
+		status int
+		err    bool
+	}{
+		{path: "/twtxt.txt", body: feed},
+		{path: "/moved.txt", body: feed},
+		{path: "/temporary", body: feed},
+		{path: "/no-meta", body: feed},
+		{path: "/loop", err: true},
+		{path: "/https", err: true},
+		{path: "/slow", status: 44, err: true},
+		{path: "/gone", status: 52, err: true},
+		{path: "/search", status: 10, err: true},
+		{path: "/client-cert", status: 60, err: true},
+		{path: "/missing", status: 51, err: true},
+		{path: "/image.png", err: true},
+		{path: "/bad-header", err: true},
+	}
+
+	fetcher := &Fetcher{Timeout: 5 * time.Second, Dir: t.TempDir()}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.path, func(t *testing.T) {
+			body, err := fetcher.Get(context.Background(), server.url(test.path))
+
+			if (err != nil) != test.err {
+				t.Fatalf("err = %v, want error %t", err, test.err)
+			}
+
+			var fetchErr *Error
+			if err != nil && !errors.As(err, &fetchErr) {
+				t.Errorf("have error %T, want *Error", err)
+			}
+
+			var statusErr *StatusError
+			if errors.As(err, &statusErr) != (test.status != 0) || (statusErr != nil && statusErr.Status != test.status) {
+				t.Errorf("have error %v, want status %d", err, test.status)
+			}
+
+			if have := string(body); have != test.body {
+				t.Errorf("have body %q, want %q", have, test.body)
+			}
+		})
+	}
+}
+
+func TestGeminiFetch(t *testing.T) {
+	dir := t.TempDir()
+	feed := "# nick = alice\n2016-02-04T13:30:00+01:00\tFiat lux!\n"
+
+	server := serve(t, certificate(t, time.Now().Add(time.Hour)), map[string]string{
+		"/twtxt.txt": "20 text/plain\r\n" + feed,
+	})
+
+	file, err := (&Fetcher{Dir: dir}).Fetch(context.Background(), server.url("/twtxt.txt"))
+	if err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	if have := len(file.Tweets); have != 1 || file.Tweets[0].Text() != "Fiat lux!" {
+		t.Errorf("have %d tweets, want %q", have, "Fiat lux!")
+	}
+
+	// the server changes its certificate, a new fetcher reads the pins that
+	// were saved by the first one
+	server.mu.Lock()
+	server.cert = certificate(t, time.Now().Add(time.Hour))
+	server.mu.Unlock()
+
+	_, err = (&Fetcher{Dir: dir}).Fetch(context.Background(), server.url("/twtxt.txt"))
+
+	var certErr *CertificateError
+	if !errors.As(err, &certErr) {
+		t.Fatalf("have error %v, want a CertificateError", err)
+	}
+
+	if have, want := certErr.Host, server.listener.Addr().String(); have != want {
+		t.Errorf("have host %q, want %q", have, want)
+	}
+}
+
+func TestGeminiPinsDir(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", dir)
+	t.Setenv("HOME", dir)
+
+	tests := []struct {
+		name    string
+		fetcher *Fetcher
+		want    string
+	}{
+		{
+			name:    "dir",
+			fetcher: &Fetcher{Dir: filepath.Join(dir, "state")},
+			want:    filepath.Join(dir, "state", "gemini_known_hosts"),
+		},
+		{
+			name:    "cache",
+			fetcher: &Fetcher{Cache: &Cache{Dir: filepath.Join(dir, "feeds")}},
+			want:    filepath.Join(dir, "feeds", "gemini_known_hosts"),
+		},
+		{
+			name:    "default",
+			fetcher: &Fetcher{},
+			want:    filepath.Join(dir, "twtr", "gemini_known_hosts"),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			if have := test.fetcher.gemini().Pins.path; have != test.want {
+				t.Errorf("have %q, want %q", have, test.want)
+			}
+		})
+	}
+}
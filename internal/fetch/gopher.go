@@ -0,0 +1,96 @@
+package fetch
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// Gopher fetches feeds over the Gopher protocol, feeds have to be text files,
+// i.e. item type 0, e.g. gopher://example.org/0/~alice/twtxt.txt.
+//
+// See RFC 1436 and RFC 4266 for more information.
+type Gopher struct {
+	Dialer net.Dialer
+}
+
+// Get fetches the contents of the feed at the url.
+func (g *Gopher) Get(ctx context.Context, u *url.URL) ([]byte, error) {
+	if u.Host == "" {
+		return nil, errors.New("gopher: missing host")
+	}
+
+	host := u.Host
+	if u.Port() == "" {
+		host = net.JoinHostPort(u.Hostname(), "70")
+	}
+
+	// the path is the item type followed by the selector
+	path := strings.TrimPrefix(u.Path, "/")
+	if path == "" {
+		return nil, errors.New("gopher: missing item type")
+	}
+
+	if path[0] != '0' {
+		return nil, fmt.Errorf("gopher: unsupported item type: %q", path[0])
+	}
+
+	selector := path[1:]
+
+	conn, err := g.Dialer.DialContext(ctx, "tcp", host)
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	if _, err := io.WriteString(conn, selector+"\r\n"); err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	// servers usually signal errors with an error menu item
+	if bytes.HasPrefix(body, []byte("3")) && bytes.Contains(firstLine(body), []byte("\t")) {
+		return nil, fmt.Errorf("gopher: %s", bytes.SplitN(firstLine(body)[1:], []byte("\t"), 2)[0])
+	}
+
+	return trimLastLine(body), nil
+}
+
+// firstLine is a helper to Get(), it returns the first line of the body.
+func firstLine(body []byte) []byte {
+	if i := bytes.IndexByte(body, '\n'); i >= 0 {
+		return bytes.TrimSuffix(body[:i], []byte("\r"))
+	}
+
+	return body
+}
+
+// trimLastLine is a helper to Get(), it removes the line with a single "." that
+// can end a text file.
+func trimLastLine(body []byte) []byte {
+	for _, last := range []string{".\r\n", ".\n", "."} {
+		if bytes.HasSuffix(body, []byte(last)) {
+			rest := body[:len(body)-len(last)]
+
+			if len(rest) == 0 || rest[len(rest)-1] == '\n' {
+				return rest
+			}
+		}
+	}
+
+	return body
+}
@@ -0,0 +1,106 @@
+package fetch
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGopher(t *testing.T) {
+	feed := "# nick = alice\r\n2016-02-04T13:30:00+01:00\tFiat lux!\r\n"
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	defer listener.Close()
+
+	// the responses of the in-process gopher server, by selector
+	responses := map[string]string{
+		"/~alice/twtxt.txt":     feed + ".\r\n",
+		"/~alice/no-end.txt":    feed,
+		"/~alice/dot-start.txt": ".plan\n",
+		"/~bob/slow.txt":        "",
+	}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go func(conn net.Conn) {
+				defer conn.Close()
+
+				selector, _ := bufio.NewReader(conn).ReadString('\n')
+				selector = strings.TrimRight(selector, "\r\n")
+
+				if selector == "/~bob/slow.txt" {
+					time.Sleep(200 * time.Millisecond)
+				}
+
+				resp, ok := responses[selector]
+				if !ok {
+					resp = "3'" + selector + "' does not exist\terror.host\t1\r\n.\r\n"
+				}
+
+				conn.Write([]byte(resp))
+			}(conn)
+		}
+	}()
+
+	base := "gopher://" + listener.Addr().String()
+
+	tests := []struct {
+		url  string
+		body string
+		err  bool
+	}{
+		{url: base + "/0/~alice/twtxt.txt", body: feed},
+		{url: base + "/0/~alice/no-end.txt", body: feed},
+		{url: base + "/0/~alice/dot-start.txt", body: ".plan\n"},
+		{url: base + "/0/~alice/missing.txt", err: true},
+		{url: base + "/1/~alice", err: true},
+		{url: base + "/", err: true},
+		{url: base + "/0/~bob/slow.txt", err: true},
+		{url: "gopher:///0/twtxt.txt", err: true},
+	}
+
+	fetcher := &Fetcher{Timeout: 100 * time.Millisecond}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.url, func(t *testing.T) {
+			body, err := fetcher.Get(context.Background(), test.url)
+
+			if (err != nil) != test.err {
+				t.Fatalf("err = %v, want error %t", err, test.err)
+			}
+
+			var fetchErr *Error
+			if err != nil && !errors.As(err, &fetchErr) {
+				t.Errorf("have error %T, want *Error", err)
+			}
+
+			if have := string(body); have != test.body {
+				t.Errorf("have body %q, want %q", have, test.body)
+			}
+		})
+	}
+
+	file, err := fetcher.Fetch(context.Background(), base+"/0/~alice/twtxt.txt")
+	if err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	if have := len(file.Tweets); have != 1 || file.Tweets[0].Text() != "Fiat lux!" {
+		t.Errorf("have %d tweets, want %q", have, "Fiat lux!")
+	}
+}
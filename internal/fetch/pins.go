@@ -0,0 +1,174 @@
+package fetch
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"duriny.envs.sh/twtr/internal/atomicfile"
+)
+
+// Pins are the certificates of the servers that have been trusted on first use,
+// saved to a file with a line for each host, its certificate's fingerprint, and
+// when the certificate expires.
+//
+//     example.org:1965 2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae 1735689600
+type Pins struct {
+	path   string
+	mu     sync.Mutex
+	loaded bool
+	hosts  map[string]pin
+}
+
+// pin is a pinned certificate.
+type pin struct {
+	fingerprint string
+	expires     time.Time
+}
+
+// CertificateError is returned when a server presents a different certificate
+// than the one that was pinned for it, before the pinned certificate expired.
+type CertificateError struct {
+	Host string
+}
+
+func (e *CertificateError) Error() string {
+	return "certificate of " + e.Host + " does not match its pinned certificate"
+}
+
+// NewPins creates Pins that are saved to the file at path, an empty path keeps
+// the Pins in memory only.
+func NewPins(path string) *Pins {
+	return &Pins{
+		path:  path,
+		hosts: make(map[string]pin),
+	}
+}
+
+// Verify checks the certificate of the host against its pin, a host that has
+// no pin, or whose pinned certificate has expired, is trusted and pinned.
+// Returns a CertificateError if the certificate does not match.
+func (p *Pins) Verify(host string, cert *x509.Certificate, now time.Time) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.load(); err != nil {
+		return err
+	}
+
+	sum := fingerprint(cert)
+
+	if pinned, ok := p.hosts[host]; ok {
+		if pinned.fingerprint == sum {
+			return nil
+		}
+
+		if now.Before(pinned.expires) {
+			return &CertificateError{Host: host}
+		}
+	}
+
+	p.hosts[host] = pin{fingerprint: sum, expires: cert.NotAfter}
+
+	return p.save()
+}
+
+// Forget removes the pin of the host, so that its next certificate is trusted.
+func (p *Pins) Forget(host string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.load(); err != nil {
+		return err
+	}
+
+	delete(p.hosts, host)
+
+	return p.save()
+}
+
+// load is a helper to Verify() and Forget(), it reads the pins from their file
+// the first time they are needed.
+func (p *Pins) load() error {
+	if p.loaded || p.path == "" {
+		return nil
+	}
+
+	f, err := os.Open(p.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		p.loaded = true
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		if len(fields) != 3 {
+			return fmt.Errorf("%s:%d: invalid pin", p.path, line)
+		}
+
+		expires, err := strconv.ParseInt(fields[2], 10, 64)
+		if err != nil {
+			return fmt.Errorf("%s:%d: invalid pin expiry", p.path, line)
+		}
+
+		p.hosts[fields[0]] = pin{fingerprint: fields[1], expires: time.Unix(expires, 0)}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	p.loaded = true
+
+	return nil
+}
+
+// save is a helper to Verify() and Forget(), it writes the pins to their file.
+func (p *Pins) save() error {
+	if p.path == "" {
+		return nil
+	}
+
+	hosts := make([]string, 0, len(p.hosts))
+	for host := range p.hosts {
+		hosts = append(hosts, host)
+	}
+
+	sort.Strings(hosts)
+
+	var b strings.Builder
+	for _, host := range hosts {
+		fmt.Fprintf(&b, "%s %s %d\n", host, p.hosts[host].fingerprint, p.hosts[host].expires.Unix())
+	}
+
+	return atomicfile.WriteFile(p.path, []byte(b.String()), 0o644)
+}
+
+// fingerprint is a helper to Pins.Verify(), it returns the SHA-256 fingerprint
+// of the certificate.
+func fingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,58 @@
+package fetch
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestPins(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gemini_known_hosts")
+	now := time.Now()
+
+	first := certificate(t, now.Add(time.Hour)).Leaf
+	second := certificate(t, now.Add(2*time.Hour)).Leaf
+
+	pins := NewPins(path)
+
+	// trusted on first use
+	if err := pins.Verify("example.org:1965", first, now); err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	if err := pins.Verify("example.org:1965", first, now); err != nil {
+		t.Errorf("unexpected error for the pinned certificate: %q", err)
+	}
+
+	// pins are per host
+	if err := pins.Verify("example.com:1965", second, now); err != nil {
+		t.Errorf("unexpected error for a new host: %q", err)
+	}
+
+	// the pins are saved between runs
+	pins = NewPins(path)
+
+	var certErr *CertificateError
+	if err := pins.Verify("example.org:1965", second, now); !errors.As(err, &certErr) {
+		t.Errorf("have error %v, want a CertificateError", err)
+	}
+
+	// a new certificate is trusted once the pinned certificate expires
+	if err := pins.Verify("example.org:1965", second, now.Add(90*time.Minute)); err != nil {
+		t.Errorf("unexpected error after the pinned certificate expired: %q", err)
+	}
+
+	if err := pins.Verify("example.org:1965", first, now); !errors.As(err, &certErr) {
+		t.Errorf("have error %v, want a CertificateError", err)
+	}
+
+	// forgetting a pin trusts the next certificate
+	if err := pins.Forget("example.org:1965"); err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	if err := NewPins(path).Verify("example.org:1965", first, now); err != nil {
+		t.Errorf("unexpected error after forgetting the pin: %q", err)
+	}
+}
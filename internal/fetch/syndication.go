@@ -0,0 +1,380 @@
+package fetch
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"duriny.envs.sh/twtr/twtxt"
+)
+
+// Syndication formats that a feed can be followed in, besides twtxt.
+const (
+	RSS      = "rss"
+	Atom     = "atom"
+	JSONFeed = "jsonfeed"
+)
+
+// ErrNoItems is returned when a syndication feed has no items with a date, so
+// there are no Tweets to convert it to.
+var ErrNoItems = errors.New("no dated items in feed")
+
+// Detect reports the syndication format of a feed, one of RSS, Atom, or
+// JSONFeed, from its contents. An empty format means the feed is not one of
+// these, and should be a twtxt feed.
+//
+// A twtxt feed starts with either a comment or a timestamp, so it can never
+// be confused with an XML or JSON document.
+func Detect(body []byte) string {
+	body = bytes.TrimPrefix(body, []byte("\xef\xbb\xbf"))
+	body = bytes.TrimSpace(body)
+
+	switch {
+	case bytes.HasPrefix(body, []byte("<")):
+		dec := newXMLDecoder(body)
+
+		for {
+			token, err := dec.Token()
+			if err != nil {
+				return ""
+			}
+
+			if start, ok := token.(xml.StartElement); ok {
+				switch start.Name.Local {
+				case "rss", "RDF":
+					return RSS
+				case "feed":
+					return Atom
+				default:
+					return ""
+				}
+			}
+		}
+	case bytes.HasPrefix(body, []byte("{")):
+		var feed struct {
+			Version string `json:"version"`
+		}
+
+		if json.Unmarshal(body, &feed) == nil && strings.HasPrefix(feed.Version, "https://jsonfeed.org/version/") {
+			return JSONFeed
+		}
+	}
+
+	return ""
+}
+
+// Convert converts a syndication feed, in the format reported by Detect, into
+// a twtxt File. Each item becomes a Tweet of its title and link, posted at the
+// date that the item was published, items without a date are skipped. The
+// description and link of the feed are kept as metadata Fields.
+func Convert(body []byte, format string) (*twtxt.File, error) {
+	var (
+		feed *syndication
+		err  error
+	)
+
+	switch format {
+	case RSS:
+		feed, err = parseRSS(body)
+	case Atom:
+		feed, err = parseAtom(body)
+	case JSONFeed:
+		feed, err = parseJSONFeed(body)
+	default:
+		return nil, errors.New("unknown syndication format: " + format)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	file := &twtxt.File{
+		Fields: make(twtxt.Fields, 0),
+		Tweets: make(twtxt.Tweets, 0, len(feed.items)),
+	}
+
+	if feed.description != "" {
+		file.Fields = append(file.Fields, twtxt.NewField("description", feed.description))
+	}
+
+	if feed.link != "" {
+		file.Fields = append(file.Fields, twtxt.NewField("link", strings.TrimSpace(feed.title+" "+feed.link)))
+	}
+
+	for _, item := range feed.items {
+		published, ok := parseDate(item.published, item.updated)
+		if !ok {
+			continue
+		}
+
+		post := strings.TrimSpace(item.title + " " + item.link)
+		if post == "" {
+			continue
+		}
+
+		file.Tweets = append(file.Tweets, twtxt.NewTweetAt(post, published))
+	}
+
+	if len(file.Tweets) == 0 && len(feed.items) > 0 {
+		return nil, ErrNoItems
+	}
+
+	// feeds are usually newest first, twtxt files oldest first
+	sort.Stable(file.Tweets)
+
+	return file, nil
+}
+
+// syndication is a helper to Convert(), it is the common part of each format.
+type syndication struct {
+	title       string
+	link        string
+	description string
+	items       []syndicationItem
+}
+
+type syndicationItem struct {
+	title     string
+	link      string
+	published string
+	updated   string
+}
+
+// rssFeed is an RSS 2.0 feed, or an RSS 1.0 (RDF) feed, which has its items
+// next to the channel rather than inside it. The links are lists, as many RSS
+// feeds also have an empty atom:link to themselves.
+type rssFeed struct {
+	Channel struct {
+		Title       string    `xml:"title"`
+		Links       []string  `xml:"link"`
+		Description string    `xml:"description"`
+		Items       []rssItem `xml:"item"`
+	} `xml:"channel"`
+	Items []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title   string   `xml:"title"`
+	Links   []string `xml:"link"`
+	GUID    string   `xml:"guid"`
+	PubDate string   `xml:"pubDate"`
+	Date    string   `xml:"http://purl.org/dc/elements/1.1/ date"`
+}
+
+// parseRSS is a helper to Convert(), it parses an RSS feed.
+func parseRSS(body []byte) (*syndication, error) {
+	var rss rssFeed
+
+	if err := unmarshalXML(body, &rss); err != nil {
+		return nil, err
+	}
+
+	feed := &syndication{
+		title:       clean(rss.Channel.Title),
+		link:        first(rss.Channel.Links),
+		description: clean(rss.Channel.Description),
+	}
+
+	for _, item := range append(rss.Channel.Items, rss.Items...) {
+		link := first(item.Links)
+		if link == "" && strings.Contains(item.GUID, "://") {
+			link = strings.TrimSpace(item.GUID)
+		}
+
+		feed.items = append(feed.items, syndicationItem{
+			title:     clean(item.Title),
+			link:      link,
+			published: item.PubDate,
+			updated:   item.Date,
+		})
+	}
+
+	return feed, nil
+}
+
+// first is a helper to parseRSS(), it returns the first link that isn't empty.
+func first(links []string) string {
+	for _, link := range links {
+		if link = strings.TrimSpace(link); link != "" {
+			return link
+		}
+	}
+
+	return ""
+}
+
+// atomFeed is an Atom feed, see RFC 4287.
+type atomFeed struct {
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle"`
+	Links    []atomLink  `xml:"link"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+}
+
+type atomEntry struct {
+	Title     string     `xml:"title"`
+	Links     []atomLink `xml:"link"`
+	Published string     `xml:"published"`
+	Updated   string     `xml:"updated"`
+}
+
+// alternate is a helper to parseAtom(), it returns the alternate link of an
+// Atom feed or entry, a link without a rel is an alternate link.
+func alternate(links []atomLink) string {
+	for _, link := range links {
+		if link.Rel == "" || link.Rel == "alternate" {
+			return strings.TrimSpace(link.Href)
+		}
+	}
+
+	return ""
+}
+
+// parseAtom is a helper to Convert(), it parses an Atom feed.
+func parseAtom(body []byte) (*syndication, error) {
+	var atom atomFeed
+
+	if err := unmarshalXML(body, &atom); err != nil {
+		return nil, err
+	}
+
+	feed := &syndication{
+		title:       clean(atom.Title),
+		link:        alternate(atom.Links),
+		description: clean(atom.Subtitle),
+	}
+
+	for _, entry := range atom.Entries {
+		feed.items = append(feed.items, syndicationItem{
+			title:     clean(entry.Title),
+			link:      alternate(entry.Links),
+			published: entry.Published,
+			updated:   entry.Updated,
+		})
+	}
+
+	return feed, nil
+}
+
+// jsonFeed is a JSON Feed, see https://www.jsonfeed.org/version/1.1/.
+type jsonFeed struct {
+	Title       string `json:"title"`
+	HomePageURL string `json:"home_page_url"`
+	Description string `json:"description"`
+	Items       []struct {
+		Title         string `json:"title"`
+		URL           string `json:"url"`
+		ExternalURL   string `json:"external_url"`
+		ContentText   string `json:"content_text"`
+		DatePublished string `json:"date_published"`
+		DateModified  string `json:"date_modified"`
+	} `json:"items"`
+}
+
+// parseJSONFeed is a helper to Convert(), it parses a JSON Feed. Items of a
+// JSON Feed don't need a title, so the first line of their text is used.
+func parseJSONFeed(body []byte) (*syndication, error) {
+	var jf jsonFeed
+
+	if err := json.Unmarshal(body, &jf); err != nil {
+		return nil, err
+	}
+
+	feed := &syndication{
+		title:       clean(jf.Title),
+		link:        strings.TrimSpace(jf.HomePageURL),
+		description: clean(jf.Description),
+	}
+
+	for _, item := range jf.Items {
+		title := item.Title
+		if title == "" {
+			title = strings.SplitN(strings.TrimSpace(item.ContentText), "\n", 2)[0]
+		}
+
+		link := item.URL
+		if link == "" {
+			link = item.ExternalURL
+		}
+
+		feed.items = append(feed.items, syndicationItem{
+			title:     clean(title),
+			link:      strings.TrimSpace(link),
+			published: item.DatePublished,
+			updated:   item.DateModified,
+		})
+	}
+
+	return feed, nil
+}
+
+// unmarshalXML is a helper to the XML parsers, it decodes the feed into v.
+func unmarshalXML(body []byte, v interface{}) error {
+	return newXMLDecoder(body).Decode(v)
+}
+
+// newXMLDecoder is a helper to Detect() and unmarshalXML(), feeds in the wild
+// are often not quite valid XML, so they are parsed leniently.
+func newXMLDecoder(body []byte) *xml.Decoder {
+	dec := xml.NewDecoder(bytes.NewReader(body))
+	dec.Strict = false
+	dec.Entity = xml.HTMLEntity
+
+	// feeds in other encodings are read as is, which is fine for ASCII
+	dec.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		return input, nil
+	}
+
+	return dec
+}
+
+// clean is a helper to the parsers, it collapses the whitespace of a title, so
+// that it fits on a single line of a twtxt file.
+func clean(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// dateLayouts are the layouts that the dates of syndication feeds are parsed
+// with, RFC 3339 for Atom and JSON Feed, RFC 822 and its variants for RSS.
+var dateLayouts = []string{
+	time.RFC3339,
+	time.RFC1123Z,
+	time.RFC1123,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"2 Jan 2006 15:04:05 -0700",
+	"2 Jan 2006 15:04:05 MST",
+	time.RFC822Z,
+	time.RFC822,
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+// parseDate is a helper to Convert(), it parses the first of the dates that is
+// set, and reports if it could be parsed.
+func parseDate(dates ...string) (time.Time, bool) {
+	for _, date := range dates {
+		date = strings.TrimSpace(date)
+		if date == "" {
+			continue
+		}
+
+		for _, layout := range dateLayouts {
+			if t, err := time.Parse(layout, date); err == nil {
+				return t, true
+			}
+		}
+	}
+
+	return time.Time{}, false
+}
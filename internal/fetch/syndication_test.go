@@ -0,0 +1,228 @@
+package fetch
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "RSS",
+			body: "<?xml version=\"1.0\"?>\n<rss version=\"2.0\"><channel></channel></rss>",
+			want: RSS,
+		},
+		{
+			name: "RDF",
+			body: `<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns="http://purl.org/rss/1.0/"></rdf:RDF>`,
+			want: RSS,
+		},
+		{
+			name: "Atom",
+			body: "\xef\xbb\xbf  <feed xmlns=\"http://www.w3.org/2005/Atom\"></feed>",
+			want: Atom,
+		},
+		{
+			name: "JSONFeed",
+			body: `{"version": "https://jsonfeed.org/version/1.1", "items": []}`,
+			want: JSONFeed,
+		},
+		{
+			name: "JSON",
+			body: `{"version": "1.0"}`,
+			want: "",
+		},
+		{
+			name: "HTML",
+			body: "<!DOCTYPE html>\n<html><body>twtxt</body></html>",
+			want: "",
+		},
+		{
+			name: "Twtxt",
+			body: "# nick = alice\n2016-02-04T13:30:00+01:00\tFiat lux!\n",
+			want: "",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			if have, want := Detect([]byte(test.body)), test.want; have != want {
+				t.Errorf("have %q, want %q", have, want)
+			}
+		})
+	}
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		fields []string
+		tweets []string
+		err    bool
+	}{
+		{
+			name: "RSS",
+			body: `<?xml version="1.0" encoding="ISO-8859-1"?>
+<rss version="2.0" xmlns:atom="http://www.w3.org/2005/Atom">
+  <channel>
+    <title>twtr releases</title>
+    <link>https://example.org/twtr</link>
+    <atom:link href="https://example.org/twtr/rss.xml" rel="self" type="application/rss+xml" />
+    <description>Releases of
+      twtr</description>
+    <item>
+      <title>twtr v0.2.0</title>
+      <link>https://example.org/twtr/v0.2.0</link>
+      <pubDate>Sat, 5 Mar 2022 09:30:00 +1300</pubDate>
+    </item>
+    <item>
+      <title>twtr v0.1.0 &amp; more</title>
+      <guid>https://example.org/twtr/v0.1.0</guid>
+      <pubDate>Tue, 01 Feb 2022 10:00:00 GMT</pubDate>
+    </item>
+    <item>
+      <title>undated</title>
+      <link>https://example.org/twtr/undated</link>
+    </item>
+  </channel>
+</rss>`,
+			fields: []string{
+				"# description = Releases of twtr",
+				"# link = twtr releases https://example.org/twtr",
+			},
+			tweets: []string{
+				"2022-02-01T10:00:00Z\ttwtr v0.1.0 & more https://example.org/twtr/v0.1.0",
+				"2022-03-05T09:30:00+13:00\ttwtr v0.2.0 https://example.org/twtr/v0.2.0",
+			},
+		},
+		{
+			name: "RDF",
+			body: `<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns="http://purl.org/rss/1.0/">
+  <channel>
+    <title>twtr releases</title>
+  </channel>
+  <item>
+    <title>twtr v0.1.0</title>
+    <link>https://example.org/twtr/v0.1.0</link>
+    <dc:date>2022-02-01T10:00:00Z</dc:date>
+  </item>
+</rdf:RDF>`,
+			fields: []string{},
+			tweets: []string{
+				"2022-02-01T10:00:00Z\ttwtr v0.1.0 https://example.org/twtr/v0.1.0",
+			},
+		},
+		{
+			name: "Atom",
+			body: `<feed xmlns="http://www.w3.org/2005/Atom">
+  <title>twtr releases</title>
+  <subtitle>Releases of twtr</subtitle>
+  <link rel="self" href="https://example.org/twtr/atom.xml" />
+  <link href="https://example.org/twtr" />
+  <entry>
+    <title>twtr v0.2.0</title>
+    <link rel="alternate" href="https://example.org/twtr/v0.2.0" />
+    <published>2022-03-05T09:30:00+13:00</published>
+    <updated>2022-03-06T09:30:00+13:00</updated>
+  </entry>
+  <entry>
+    <title>twtr v0.1.0</title>
+    <link rel="enclosure" href="https://example.org/twtr/v0.1.0.tar.gz" />
+    <updated>2022-02-01T10:00:00Z</updated>
+  </entry>
+</feed>`,
+			fields: []string{
+				"# description = Releases of twtr",
+				"# link = twtr releases https://example.org/twtr",
+			},
+			tweets: []string{
+				"2022-02-01T10:00:00Z\ttwtr v0.1.0",
+				"2022-03-05T09:30:00+13:00\ttwtr v0.2.0 https://example.org/twtr/v0.2.0",
+			},
+		},
+		{
+			name: "JSONFeed",
+			body: `{
+  "version": "https://jsonfeed.org/version/1.1",
+  "title": "twtr releases",
+  "home_page_url": "https://example.org/twtr",
+  "items": [
+    {
+      "id": "2",
+      "content_text": "twtr v0.2.0\nwith exports",
+      "url": "https://example.org/twtr/v0.2.0",
+      "date_published": "2022-03-05T09:30:00+13:00"
+    },
+    {
+      "id": "1",
+      "title": "twtr v0.1.0",
+      "external_url": "https://example.org/twtr/v0.1.0",
+      "date_published": "2022-02-01T10:00:00Z"
+    }
+  ]
+}`,
+			fields: []string{
+				"# link = twtr releases https://example.org/twtr",
+			},
+			tweets: []string{
+				"2022-02-01T10:00:00Z\ttwtr v0.1.0 https://example.org/twtr/v0.1.0",
+				"2022-03-05T09:30:00+13:00\ttwtr v0.2.0 https://example.org/twtr/v0.2.0",
+			},
+		},
+		{
+			name: "Undated",
+			body: `<feed xmlns="http://www.w3.org/2005/Atom"><entry><title>twtr</title></entry></feed>`,
+			err:  true,
+		},
+		{
+			name: "Invalid",
+			body: `{"version": "https://jsonfeed.org/version/1.1", "items": {}}`,
+			err:  true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			file, err := Convert([]byte(test.body), Detect([]byte(test.body)))
+			if test.err {
+				if err == nil {
+					t.Errorf("expected an error")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %q", err)
+			}
+
+			fields := make([]string, 0)
+			for _, field := range file.Fields {
+				fields = append(fields, field.String())
+			}
+
+			if !cmp.Equal(fields, test.fields) {
+				t.Errorf("fields diff:\n%s", cmp.Diff(fields, test.fields))
+			}
+
+			tweets := make([]string, 0)
+			for _, tweet := range file.Tweets {
+				tweets = append(tweets, tweet.String())
+			}
+
+			if !cmp.Equal(tweets, test.tweets) {
+				t.Errorf("tweets diff:\n%s", cmp.Diff(tweets, test.tweets))
+			}
+		})
+	}
+}
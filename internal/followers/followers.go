@@ -0,0 +1,157 @@
+package followers
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"duriny.envs.sh/twtr/twtxt/useragent"
+)
+
+// Follower is a twtxt client that has fetched your feed, identified by the url
+// and nick in its User-Agent.
+type Follower struct {
+	Nick      string
+	URL       string
+	Client    string
+	FirstSeen time.Time
+	LastSeen  time.Time
+	Requests  int
+}
+
+// Followers collects the Followers from the access logs of the web server that
+// hosts your feed.
+type Followers struct {
+	// Path is the path of your feed on the web server, requests for any other
+	// path are ignored. An empty Path means every request is counted.
+	Path string
+
+	// Skipped is the number of lines that could not be parsed.
+	Skipped int
+
+	followers map[string]*Follower
+}
+
+// New creates an empty collection of the Followers of the feed at path.
+func New(path string) *Followers {
+	return &Followers{
+		Path:      path,
+		followers: make(map[string]*Follower),
+	}
+}
+
+// Read adds the Followers found in an access log, the log may be compressed
+// with gzip. Read can be called with each rotation of the same log.
+func (f *Followers) Read(r io.Reader) error {
+	return readLog(r, func(line string) {
+		req, err := ParseRequest(line)
+		if err != nil {
+			f.Skipped++
+			return
+		}
+
+		f.Add(req)
+	})
+}
+
+// Add adds the Followers announced in the User-Agent of a single Request, if it
+// is a successful request for the feed. A multi-user yarnd pod announces many
+// Followers in one Request.
+func (f *Followers) Add(req *Request) {
+	if f.Path != "" && strings.SplitN(req.Path, "?", 2)[0] != f.Path {
+		return
+	}
+
+	if req.Status != 304 && (req.Status < 200 || req.Status > 299) {
+		return
+	}
+
+	agent, err := useragent.Parse(req.UserAgent)
+	if err != nil {
+		return
+	}
+
+	for _, announced := range agent.Followers {
+		if announced.URL == "" {
+			continue
+		}
+
+		follower := f.followers[announced.URL]
+		if follower == nil {
+			follower = &Follower{
+				URL:       announced.URL,
+				FirstSeen: req.Time,
+			}
+
+			f.followers[announced.URL] = follower
+		}
+
+		follower.Requests++
+
+		if req.Time.Before(follower.FirstSeen) {
+			follower.FirstSeen = req.Time
+		}
+
+		// the nick and client may change, keep the latest
+		if !req.Time.Before(follower.LastSeen) {
+			follower.LastSeen = req.Time
+			follower.Nick = announced.Nick
+			follower.Client = agent.Client + "/" + agent.Version
+		}
+	}
+}
+
+// List returns the Followers, most recently seen first.
+func (f *Followers) List() []*Follower {
+	list := make([]*Follower, 0, len(f.followers))
+	for _, follower := range f.followers {
+		list = append(list, follower)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		if !list[i].LastSeen.Equal(list[j].LastSeen) {
+			return list[i].LastSeen.After(list[j].LastSeen)
+		}
+
+		return list[i].URL < list[j].URL
+	})
+
+	return list
+}
+
+// WriteTo writes the Followers as twtxt metadata fields, the number of
+// followers followed by a follower field for each of them, so they can be
+// added to the head of your feed.
+//
+//     # followers = 2
+//     # follower = alice https://example.org/alice.txt
+//     # follower = bob https://example.org/bob.txt
+func (f *Followers) WriteTo(w io.Writer) (int64, error) {
+	list := f.List()
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Nick < list[j].Nick
+	})
+
+	var total int64
+
+	n, err := fmt.Fprintf(w, "# followers = %d\n", len(list))
+	total += int64(n)
+
+	if err != nil {
+		return total, err
+	}
+
+	for _, follower := range list {
+		n, err := fmt.Fprintf(w, "# follower = %s %s\n", follower.Nick, follower.URL)
+		total += int64(n)
+
+		if err != nil {
+			return total, err
+		}
+	}
+
+	return total, nil
+}
@@ -0,0 +1,106 @@
+package followers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFollowers(t *testing.T) {
+	// the current log, and an older rotation compressed with gzip
+	current := strings.Join([]string{
+		`203.0.113.7 - - [02/Mar/2022:09:00:00 +0000] "GET /twtxt.txt HTTP/1.1" 200 1024 "-" "twtxt/1.2.3 (+https://example.org/alice.txt; @alice)"`,
+		`203.0.113.8 - - [02/Mar/2022:10:00:00 +0000] "GET /twtxt.txt HTTP/1.1" 304 0 "-" "twtr/0.2.0 (+https://example.org/bob.txt; @bobby)"`,
+		`203.0.113.9 - - [02/Mar/2022:11:00:00 +0000] "GET /twtxt.txt HTTP/1.1" 200 1024 "-" "Mozilla/5.0 (X11; Linux x86_64)"`,
+		`203.0.113.9 - - [02/Mar/2022:11:00:00 +0000] "GET /index.html HTTP/1.1" 200 1024 "-" "twtxt/1.2.3 (+https://example.org/carol.txt; @carol)"`,
+		`203.0.113.9 - - [02/Mar/2022:12:00:00 +0000] "GET /twtxt.txt HTTP/1.1" 404 0 "-" "twtxt/1.2.3 (+https://example.org/carol.txt; @carol)"`,
+		`203.0.113.9 - - [02/Mar/2022:12:00:00 +0000] "GET /twtxt.txt HTTP/1.1" 200 1024`,
+		`198.51.100.1 - - [02/Mar/2022:08:00:00 +0000] "GET /twtxt.txt HTTP/1.1" 200 1024 "-" "yarnd/0.13.0@a1b2c3d (Pod: pod.example Followers: erin frank Support: https://pod.example/support)"`,
+		`not a log line`,
+		``,
+	}, "\n")
+
+	rotated := strings.Join([]string{
+		`203.0.113.8 - - [01/Mar/2022:10:00:00 +0000] "GET /twtxt.txt?since=1 HTTP/1.1" 200 1024 "-" "twtxt/1.2.3 (+https://example.org/bob.txt; @bob)"`,
+		`203.0.113.7 - - [01/Mar/2022:09:00:00 +0000] "GET /twtxt.txt HTTP/1.1" 200 1024 "-" "twtxt/1.2.3 (+https://example.org/alice.txt; @alice)"`,
+	}, "\n")
+
+	var gz bytes.Buffer
+
+	w := gzip.NewWriter(&gz)
+	w.Write([]byte(rotated))
+	w.Close()
+
+	followers := New("/twtxt.txt")
+
+	if err := followers.Read(strings.NewReader(current)); err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	if err := followers.Read(&gz); err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	if have, want := followers.Skipped, 1; have != want {
+		t.Errorf("skipped %d lines, want %d", have, want)
+	}
+
+	want := []*Follower{
+		{
+			Nick:      "bobby",
+			URL:       "https://example.org/bob.txt",
+			Client:    "twtr/0.2.0",
+			FirstSeen: time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC),
+			LastSeen:  time.Date(2022, 3, 2, 10, 0, 0, 0, time.UTC),
+			Requests:  2,
+		},
+		{
+			Nick:      "alice",
+			URL:       "https://example.org/alice.txt",
+			Client:    "twtxt/1.2.3",
+			FirstSeen: time.Date(2022, 3, 1, 9, 0, 0, 0, time.UTC),
+			LastSeen:  time.Date(2022, 3, 2, 9, 0, 0, 0, time.UTC),
+			Requests:  2,
+		},
+		{
+			Nick:      "erin",
+			URL:       "https://pod.example/user/erin/twtxt.txt",
+			Client:    "yarnd/0.13.0@a1b2c3d",
+			FirstSeen: time.Date(2022, 3, 2, 8, 0, 0, 0, time.UTC),
+			LastSeen:  time.Date(2022, 3, 2, 8, 0, 0, 0, time.UTC),
+			Requests:  1,
+		},
+		{
+			Nick:      "frank",
+			URL:       "https://pod.example/user/frank/twtxt.txt",
+			Client:    "yarnd/0.13.0@a1b2c3d",
+			FirstSeen: time.Date(2022, 3, 2, 8, 0, 0, 0, time.UTC),
+			LastSeen:  time.Date(2022, 3, 2, 8, 0, 0, 0, time.UTC),
+			Requests:  1,
+		},
+	}
+
+	if diff := cmp.Diff(want, followers.List(), cmp.Comparer(time.Time.Equal)); diff != "" {
+		t.Errorf("unexpected followers (-want +have):\n%s", diff)
+	}
+
+	var stats strings.Builder
+	if _, err := followers.WriteTo(&stats); err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	wantStats := `# followers = 4
+# follower = alice https://example.org/alice.txt
+# follower = bobby https://example.org/bob.txt
+# follower = erin https://pod.example/user/erin/twtxt.txt
+# follower = frank https://pod.example/user/frank/twtxt.txt
+`
+
+	if diff := cmp.Diff(wantStats, stats.String()); diff != "" {
+		t.Errorf("unexpected stats (-want +have):\n%s", diff)
+	}
+}
@@ -0,0 +1,113 @@
+package followers
+
+import (
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Request is a single line of a web server access log, in either the common or
+// the combined log format, only the combined format has a User-Agent.
+//
+//     host ident user [time] "request" status bytes
+//     host ident user [time] "request" status bytes "referer" "user-agent"
+type Request struct {
+	Host      string
+	Time      time.Time
+	Method    string
+	Path      string
+	Status    int
+	UserAgent string
+}
+
+// ErrInvalidLine is returned by ParseRequest for a line that is not in the
+// common or combined log format.
+var ErrInvalidLine = errors.New("invalid access log line")
+
+// logTime is the layout of the timestamps in an access log.
+const logTime = "02/Jan/2006:15:04:05 -0700"
+
+// logLine matches a line in the common or combined log format.
+var logLine = regexp.MustCompile(`^(\S+) \S+ \S+ \[([^\]]+)\] "((?:[^"\\]|\\.)*)" (\d{3}) \S+(?: "(?:[^"\\]|\\.)*" "((?:[^"\\]|\\.)*)")?`)
+
+// ParseRequest parses a single line of an access log.
+func ParseRequest(line string) (*Request, error) {
+	match := logLine.FindStringSubmatch(line)
+	if match == nil {
+		return nil, ErrInvalidLine
+	}
+
+	t, err := time.Parse(logTime, match[2])
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := strconv.Atoi(match[4])
+	if err != nil {
+		return nil, err
+	}
+
+	req := &Request{
+		Host:      match[1],
+		Time:      t,
+		Status:    status,
+		UserAgent: unescape(match[5]),
+	}
+
+	// the request line is "METHOD PATH PROTOCOL", but may be garbage
+	if fields := strings.Fields(unescape(match[3])); len(fields) >= 2 {
+		req.Method = fields[0]
+		req.Path = fields[1]
+	}
+
+	return req, nil
+}
+
+// unescape is a helper to ParseRequest(), it reverses the escaping of quotes
+// and backslashes in a quoted field.
+func unescape(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+
+	return strings.NewReplacer(`\"`, `"`, `\\`, `\`).Replace(s)
+}
+
+// readLog is a helper to Followers.Read(), it calls fn for each line of an
+// access log, decompressing the log first if it was rotated with gzip.
+func readLog(r io.Reader, fn func(line string)) error {
+	br := bufio.NewReader(r)
+
+	// gzip streams start with the magic bytes 0x1f 0x8b
+	if magic, err := br.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return err
+		}
+
+		defer gz.Close()
+
+		br = bufio.NewReader(gz)
+	}
+
+	for {
+		line, err := br.ReadString('\n')
+
+		if line = strings.TrimRight(line, "\r\n"); line != "" {
+			fn(line)
+		}
+
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package followers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want *Request
+		err  bool
+	}{
+		{
+			name: "Common",
+			line: `203.0.113.7 - - [01/Mar/2022:09:30:00 +1300] "GET /twtxt.txt HTTP/1.1" 200 1024`,
+			want: &Request{
+				Host:   "203.0.113.7",
+				Time:   time.Date(2022, 2, 28, 20, 30, 0, 0, time.UTC),
+				Method: "GET",
+				Path:   "/twtxt.txt",
+				Status: 200,
+			},
+		},
+		{
+			name: "Combined",
+			line: `203.0.113.7 - - [01/Mar/2022:09:30:00 +0000] "GET /twtxt.txt HTTP/1.1" 304 - "-" "twtxt/1.2.3 (+https://example.org/alice.txt; @alice)"`,
+			want: &Request{
+				Host:      "203.0.113.7",
+				Time:      time.Date(2022, 3, 1, 9, 30, 0, 0, time.UTC),
+				Method:    "GET",
+				Path:      "/twtxt.txt",
+				Status:    304,
+				UserAgent: "twtxt/1.2.3 (+https://example.org/alice.txt; @alice)",
+			},
+		},
+		{
+			name: "EscapedQuotes",
+			line: `2001:db8::1 - bob [01/Mar/2022:09:30:00 +0000] "GET /twtxt.txt HTTP/2.0" 200 512 "https://example.org/" "quoted \"client\""`,
+			want: &Request{
+				Host:      "2001:db8::1",
+				Time:      time.Date(2022, 3, 1, 9, 30, 0, 0, time.UTC),
+				Method:    "GET",
+				Path:      "/twtxt.txt",
+				Status:    200,
+				UserAgent: `quoted "client"`,
+			},
+		},
+		{
+			name: "GarbageRequest",
+			line: `203.0.113.7 - - [01/Mar/2022:09:30:00 +0000] "\x16\x03\x01" 400 0`,
+			want: &Request{
+				Host:   "203.0.113.7",
+				Time:   time.Date(2022, 3, 1, 9, 30, 0, 0, time.UTC),
+				Status: 400,
+			},
+		},
+		{
+			name: "BadTime",
+			line: `203.0.113.7 - - [yesterday] "GET /twtxt.txt HTTP/1.1" 200 1024`,
+			err:  true,
+		},
+		{
+			name: "NotALog",
+			line: `Fiat lux!`,
+			err:  true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			have, err := ParseRequest(test.line)
+
+			if (err != nil) != test.err {
+				t.Fatalf("err = %v, want error %t", err, test.err)
+			}
+
+			if diff := cmp.Diff(test.want, have, cmp.Comparer(time.Time.Equal)); diff != "" {
+				t.Errorf("unexpected request (-want +have):\n%s", diff)
+			}
+		})
+	}
+}
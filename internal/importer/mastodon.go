@@ -0,0 +1,213 @@
+package importer
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"strings"
+	"time"
+
+	"duriny.envs.sh/twtr/twtxt"
+)
+
+// public is the audience of public and unlisted posts in ActivityPub.
+const public = "https://www.w3.org/ns/activitystreams#Public"
+
+// outbox is the outbox.json of a Mastodon archive, an ActivityPub collection
+// of the activities of the account.
+type outbox struct {
+	OrderedItems []activity `json:"orderedItems"`
+}
+
+type activity struct {
+	Type      string          `json:"type"`
+	Published string          `json:"published"`
+	Object    json.RawMessage `json:"object"`
+}
+
+type note struct {
+	Type      string   `json:"type"`
+	Published string   `json:"published"`
+	Content   string   `json:"content"`
+	To        []string `json:"to"`
+	Cc        []string `json:"cc"`
+	Tag       []struct {
+		Type string `json:"type"`
+		Href string `json:"href"`
+		Name string `json:"name"`
+	} `json:"tag"`
+	Attachment []struct {
+		URL string `json:"url"`
+	} `json:"attachment"`
+}
+
+// Mastodon reads the outbox.json of a Mastodon archive, and returns the public
+// and unlisted posts of the account as Tweets, with their original timestamps.
+// Boosts, followers-only posts, and direct messages are skipped.
+//
+// Mentions are kept as @<nick url>, with the url of the mentioned account,
+// hashtags as #tag, and links as their full url. Attachments are added to the
+// end of the post as links, unless they are only a path within the archive.
+func Mastodon(r io.Reader) (twtxt.Tweets, error) {
+	var box outbox
+
+	if err := json.NewDecoder(r).Decode(&box); err != nil {
+		return nil, err
+	}
+
+	tweets := make(twtxt.Tweets, 0, len(box.OrderedItems))
+
+	for _, act := range box.OrderedItems {
+		if act.Type != "Create" {
+			continue
+		}
+
+		var post note
+
+		// the object of a Create is usually a Note, but can be a bare url
+		if err := json.Unmarshal(act.Object, &post); err != nil {
+			continue
+		}
+
+		if !contains(post.To, public) && !contains(post.Cc, public) {
+			continue
+		}
+
+		published, err := time.Parse(time.RFC3339, first(post.Published, act.Published))
+		if err != nil {
+			return nil, err
+		}
+
+		mentions := make(map[string]string)
+		for _, tag := range post.Tag {
+			if tag.Type == "Mention" {
+				mentions[tag.Href] = strings.SplitN(strings.TrimPrefix(tag.Name, "@"), "@", 2)[0]
+			}
+		}
+
+		text := htmlText(post.Content, mentions)
+
+		for _, attachment := range post.Attachment {
+			if strings.Contains(attachment.URL, "://") {
+				text = strings.TrimSpace(text + " " + attachment.URL)
+			}
+		}
+
+		if text == "" {
+			continue
+		}
+
+		tweets = append(tweets, twtxt.NewTweetAt(text, published))
+	}
+
+	return tweets, nil
+}
+
+// htmlText is a helper to Mastodon(), it converts the HTML content of a post
+// into plain text. Paragraphs are separated by a blank line, links to the
+// mentioned accounts become @<nick url>, hashtags keep their text, and other
+// links become their url, as Mastodon hides part of the url in the text.
+func htmlText(content string, mentions map[string]string) string {
+	dec := xml.NewDecoder(strings.NewReader("<html>" + content + "</html>"))
+	dec.Strict = false
+	dec.AutoClose = xml.HTMLAutoClose
+	dec.Entity = xml.HTMLEntity
+
+	var (
+		text   strings.Builder
+		anchor *strings.Builder // the text of the current link
+		href   string
+		depth  int // of the current link, to ignore its child elements
+	)
+
+	for {
+		token, err := dec.Token()
+		if err != nil {
+			break
+		}
+
+		switch token := token.(type) {
+		case xml.StartElement:
+			if anchor != nil {
+				depth++
+				continue
+			}
+
+			switch token.Name.Local {
+			case "a":
+				anchor, href, depth = &strings.Builder{}, attr(token, "href"), 0
+			case "br":
+				text.WriteString("\n")
+			case "p":
+				if text.Len() > 0 {
+					text.WriteString("\n\n")
+				}
+			}
+		case xml.EndElement:
+			if anchor == nil || token.Name.Local != "a" || depth > 0 {
+				if anchor != nil {
+					depth--
+				}
+
+				continue
+			}
+
+			link := strings.TrimSpace(anchor.String())
+
+			switch nick, ok := mentions[href]; {
+			case ok:
+				text.WriteString(twtxt.NewMention(nick, href).String())
+			case strings.HasPrefix(link, "@"):
+				nick = strings.SplitN(link[1:], "@", 2)[0]
+				text.WriteString(twtxt.NewMention(nick, href).String())
+			case strings.HasPrefix(link, "#"):
+				text.WriteString(link)
+			default:
+				text.WriteString(href)
+			}
+
+			anchor = nil
+		case xml.CharData:
+			if anchor != nil {
+				anchor.Write(token)
+			} else {
+				text.Write(token)
+			}
+		}
+	}
+
+	return strings.TrimSpace(text.String())
+}
+
+// attr is a helper to htmlText(), it returns the value of the named attribute.
+func attr(el xml.StartElement, name string) string {
+	for _, a := range el.Attr {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+
+	return ""
+}
+
+// contains is a helper to Mastodon(), it reports if s is in list.
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+// first is a helper to Mastodon(), it returns the first value that isn't empty.
+func first(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,79 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMastodon(t *testing.T) {
+	outbox := `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "type": "OrderedCollection",
+  "orderedItems": [
+    {
+      "type": "Create",
+      "published": "2022-11-07T09:30:00Z",
+      "object": {
+        "type": "Note",
+        "published": "2022-11-07T09:30:00Z",
+        "to": ["https://www.w3.org/ns/activitystreams#Public"],
+        "content": "<p>Hello <span class=\"h-card\"><a href=\"https://example.social/@bob\" class=\"u-url mention\">@<span>bob</span></a></span>, lunch?</p><p>See <a href=\"https://example.org/lunch/menu.html\" rel=\"nofollow noopener\"><span class=\"invisible\">https://</span><span class=\"ellipsis\">example.org/lunch/</span><span class=\"invisible\">menu.html</span></a> &amp; <a href=\"https://example.social/tags/food\" class=\"mention hashtag\">#<span>food</span></a><br>Thanks!</p>",
+        "tag": [{"type": "Mention", "href": "https://example.social/@bob", "name": "@bob@example.social"}],
+        "attachment": [
+          {"type": "Document", "url": "/media_attachments/files/menu.png"},
+          {"type": "Document", "url": "https://files.example.social/menu.png"}
+        ]
+      }
+    },
+    {
+      "type": "Create",
+      "published": "2022-11-08T10:00:00+01:00",
+      "object": {
+        "type": "Note",
+        "to": ["https://example.social/users/alice/followers"],
+        "cc": ["https://www.w3.org/ns/activitystreams#Public"],
+        "content": "<p>Unlisted</p>"
+      }
+    },
+    {
+      "type": "Create",
+      "published": "2022-11-09T10:00:00Z",
+      "object": {
+        "type": "Note",
+        "to": ["https://example.social/users/alice/followers"],
+        "content": "<p>Followers only</p>"
+      }
+    },
+    {
+      "type": "Announce",
+      "published": "2022-11-10T10:00:00Z",
+      "object": "https://example.social/users/bob/statuses/1"
+    }
+  ]
+}`
+
+	tweets, err := Mastodon(strings.NewReader(outbox))
+	if err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	have := make([]string, 0)
+	for _, tweet := range tweets {
+		have = append(have, tweet.String())
+	}
+
+	want := []string{
+		"2022-11-07T09:30:00Z\tHello @<bob https://example.social/@bob>, lunch?\\n\\nSee https://example.org/lunch/menu.html & #food\\nThanks! https://files.example.social/menu.png",
+		"2022-11-08T10:00:00+01:00\tUnlisted",
+	}
+
+	if !cmp.Equal(have, want) {
+		t.Errorf("diff:\n%s", cmp.Diff(have, want))
+	}
+
+	if _, err := Mastodon(strings.NewReader("[]")); err == nil {
+		t.Errorf("expected an error")
+	}
+}
@@ -0,0 +1,120 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"html"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"duriny.envs.sh/twtr/twtxt"
+)
+
+// ErrInvalidArchive is returned when a tweets.js file is not a Twitter archive.
+var ErrInvalidArchive = errors.New("not a twitter archive")
+
+// tweetJS is a tweet of the tweets.js file of a Twitter archive, newer archives
+// wrap each tweet in an object of its own.
+type tweetJS struct {
+	Tweet *tweetJS `json:"tweet"`
+
+	CreatedAt string `json:"created_at"`
+	FullText  string `json:"full_text"`
+	Entities  struct {
+		URLs         []tweetURL `json:"urls"`
+		Media        []tweetURL `json:"media"`
+		UserMentions []struct {
+			ScreenName string `json:"screen_name"`
+		} `json:"user_mentions"`
+	} `json:"entities"`
+	ExtendedEntities struct {
+		Media []tweetURL `json:"media"`
+	} `json:"extended_entities"`
+}
+
+type tweetURL struct {
+	URL           string `json:"url"`
+	ExpandedURL   string `json:"expanded_url"`
+	MediaURLHTTPS string `json:"media_url_https"`
+}
+
+// twitterProfile is the url of the profile of a Twitter account, which mentions
+// of the account are converted to.
+const twitterProfile = "https://twitter.com/"
+
+// Twitter reads the tweets.js file of a Twitter archive, and returns the tweets
+// of the account as Tweets, with their original timestamps. Retweets are
+// skipped.
+//
+// Mentions are kept as @<nick url>, with the url of the mentioned profile, and
+// shortened links are expanded to their full url, or the url of the image or
+// video for media.
+func Twitter(r io.Reader) (twtxt.Tweets, error) {
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// the JSON is assigned to a variable, e.g. window.YTD.tweets.part0 = [...]
+	i := bytes.IndexByte(body, '[')
+	if i < 0 {
+		return nil, ErrInvalidArchive
+	}
+
+	var archive []tweetJS
+
+	if err := json.Unmarshal(body[i:], &archive); err != nil {
+		return nil, err
+	}
+
+	tweets := make(twtxt.Tweets, 0, len(archive))
+
+	for _, tweet := range archive {
+		if tweet.Tweet != nil {
+			tweet = *tweet.Tweet
+		}
+
+		text := html.UnescapeString(tweet.FullText)
+		if strings.HasPrefix(text, "RT @") {
+			continue
+		}
+
+		created, err := time.Parse(time.RubyDate, tweet.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, u := range tweet.Entities.URLs {
+			text = strings.ReplaceAll(text, u.URL, u.ExpandedURL)
+		}
+
+		media := append(tweet.ExtendedEntities.Media, tweet.Entities.Media...)
+
+		// each media item shares the link, so only the first replaces it
+		for _, m := range media {
+			if strings.Contains(text, m.URL) {
+				text = strings.Replace(text, m.URL, m.MediaURLHTTPS, 1)
+			} else if !strings.Contains(text, m.MediaURLHTTPS) {
+				text += " " + m.MediaURLHTTPS
+			}
+		}
+
+		for _, mention := range tweet.Entities.UserMentions {
+			nick := mention.ScreenName
+			re := regexp.MustCompile(`(?i)(^|[^\w<])@` + regexp.QuoteMeta(nick) + `\b`)
+
+			text = re.ReplaceAllString(text, "${1}"+twtxt.NewMention(nick, twitterProfile+nick).String())
+		}
+
+		if text = strings.TrimSpace(text); text == "" {
+			continue
+		}
+
+		tweets = append(tweets, twtxt.NewTweetAt(text, created))
+	}
+
+	return tweets, nil
+}
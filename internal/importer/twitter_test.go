@@ -0,0 +1,65 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTwitter(t *testing.T) {
+	archive := `window.YTD.tweets.part0 = [
+  {
+    "tweet": {
+      "created_at": "Fri Feb 04 12:30:00 +0000 2022",
+      "full_text": "@Bob lunch? &lt;3 https://t.co/abc https://t.co/img",
+      "entities": {
+        "urls": [{"url": "https://t.co/abc", "expanded_url": "https://example.org/lunch"}],
+        "user_mentions": [{"screen_name": "bob"}],
+        "media": [{"url": "https://t.co/img", "media_url_https": "https://pbs.twimg.com/media/1.jpg"}]
+      },
+      "extended_entities": {
+        "media": [
+          {"url": "https://t.co/img", "media_url_https": "https://pbs.twimg.com/media/1.jpg"},
+          {"url": "https://t.co/img", "media_url_https": "https://pbs.twimg.com/media/2.jpg"}
+        ]
+      }
+    }
+  },
+  {
+    "tweet": {
+      "created_at": "Sat Feb 05 09:00:00 +0000 2022",
+      "full_text": "RT @bob: lunch!",
+      "entities": {"user_mentions": [{"screen_name": "bob"}]}
+    }
+  },
+  {
+    "created_at": "Sun Feb 06 09:00:00 +0000 2022",
+    "full_text": "emailing bob@example.org",
+    "entities": {"user_mentions": [{"screen_name": "example"}]}
+  }
+]`
+
+	tweets, err := Twitter(strings.NewReader(archive))
+	if err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	have := make([]string, 0)
+	for _, tweet := range tweets {
+		have = append(have, tweet.String())
+	}
+
+	want := []string{
+		"2022-02-04T12:30:00Z\t@<bob https://twitter.com/bob> lunch? <3 https://example.org/lunch https://pbs.twimg.com/media/1.jpg https://pbs.twimg.com/media/2.jpg",
+		"2022-02-06T09:00:00Z\temailing bob@example.org",
+	}
+
+	if !cmp.Equal(have, want) {
+		t.Errorf("diff:\n%s", cmp.Diff(have, want))
+	}
+
+	if _, err := Twitter(strings.NewReader("{}")); err == nil {
+		t.Errorf("expected an error")
+	}
+}
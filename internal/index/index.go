@@ -0,0 +1,215 @@
+package index
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"io/fs"
+	"os"
+	"strings"
+	"time"
+	"unicode"
+
+	"duriny.envs.sh/twtr/internal/atomicfile"
+	"duriny.envs.sh/twtr/internal/timeline"
+)
+
+// Doc is a Tweet that has been indexed, it holds everything needed to show the
+// Tweet in search results without reading its feed.
+type Doc struct {
+	Hash string
+	Nick string
+	URL  string
+	Time time.Time
+	Text string
+}
+
+// Index is a full-text inverted index of Tweets, keyed by their twt hash. An
+// Index is updated with the Tweets of each feed as they are fetched, and saved
+// to a file between runs.
+type Index struct {
+	path  string
+	docs  map[string]*Doc
+	terms map[string]map[string]bool
+}
+
+// file is the format of an Index saved to disk, the postings of each term are
+// saved along with the Docs, so that opening an Index doesn't tokenize every
+// Tweet again.
+type file struct {
+	Version int
+	Docs    []*Doc
+	Terms   map[string][]string
+}
+
+// version is the current version of the file format.
+const version = 2
+
+// New creates a new empty Index, that will be saved to the file at path.
+func New(path string) *Index {
+	return &Index{
+		path:  path,
+		docs:  make(map[string]*Doc),
+		terms: make(map[string]map[string]bool),
+	}
+}
+
+// Open reads the Index saved in the file at path, if there is no such file then
+// a new empty Index is returned.
+func Open(path string) (*Index, error) {
+	idx := New(path)
+
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return idx, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	var saved file
+	if err := gob.NewDecoder(f).Decode(&saved); err != nil {
+		return nil, err
+	}
+
+	// an index in an unknown format can be rebuilt from scratch
+	if saved.Version != version {
+		return idx, nil
+	}
+
+	for _, doc := range saved.Docs {
+		idx.docs[doc.Hash] = doc
+	}
+
+	for term, hashes := range saved.Terms {
+		idx.terms[term] = make(map[string]bool, len(hashes))
+
+		for _, hash := range hashes {
+			idx.terms[term][hash] = true
+		}
+	}
+
+	return idx, nil
+}
+
+// Save writes the Index to its file, see atomicfile.WriteFile.
+func (idx *Index) Save() error {
+	saved := file{
+		Version: version,
+		Docs:    make([]*Doc, 0, len(idx.docs)),
+		Terms:   make(map[string][]string, len(idx.terms)),
+	}
+
+	for _, doc := range idx.docs {
+		saved.Docs = append(saved.Docs, doc)
+	}
+
+	for term, hashes := range idx.terms {
+		for hash := range hashes {
+			saved.Terms[term] = append(saved.Terms[term], hash)
+		}
+	}
+
+	var buf bytes.Buffer
+
+	if err := gob.NewEncoder(&buf).Encode(&saved); err != nil {
+		return err
+	}
+
+	return atomicfile.WriteFile(idx.path, buf.Bytes(), 0o600)
+}
+
+// Len reports the number of Tweets in the Index.
+func (idx *Index) Len() int {
+	return len(idx.docs)
+}
+
+// Get returns the indexed Tweet with the twt hash, or nil if there is none.
+func (idx *Index) Get(hash string) *Doc {
+	return idx.docs[hash]
+}
+
+// Add indexes the Entries of a Timeline, Entries that are already in the Index
+// are skipped. Returns the number of Entries that were added.
+func (idx *Index) Add(tl timeline.Timeline) int {
+	var added int
+
+	for _, entry := range tl {
+		doc := &Doc{
+			Hash: entry.Hash(),
+			Nick: entry.Nick,
+			URL:  entry.URL,
+			Time: entry.Tweet.Time(),
+			Text: entry.Tweet.Text(),
+		}
+
+		if _, ok := idx.docs[doc.Hash]; ok {
+			continue
+		}
+
+		idx.add(doc)
+		added++
+	}
+
+	return added
+}
+
+// add is a helper to Add(), it adds the Doc to the Index.
+func (idx *Index) add(doc *Doc) {
+	idx.docs[doc.Hash] = doc
+
+	for _, term := range docTerms(doc) {
+		if idx.terms[term] == nil {
+			idx.terms[term] = make(map[string]bool)
+		}
+
+		idx.terms[term][doc.Hash] = true
+	}
+}
+
+// docTerms is a helper to add(), it returns every term that the Doc is indexed
+// by, including the field terms, e.g. "from:alice" and "tag:release".
+func docTerms(doc *Doc) []string {
+	terms := []string{
+		"from:" + strings.ToLower(doc.Nick),
+		"url:" + strings.ToLower(doc.URL),
+	}
+
+	for _, word := range tokenize(doc.Text) {
+		terms = append(terms, word)
+	}
+
+	for _, tag := range hashtags(doc.Text) {
+		terms = append(terms, "tag:"+tag)
+	}
+
+	return terms
+}
+
+// tokenize splits text into lower case words, anything that is not a letter or
+// a number separates words.
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// hashtags returns the lower case hashtags in text, without the "#".
+func hashtags(text string) []string {
+	var tags []string
+
+	for _, field := range strings.Fields(strings.ToLower(text)) {
+		if !strings.HasPrefix(field, "#") {
+			continue
+		}
+
+		if words := tokenize(field); len(words) > 0 {
+			tags = append(tags, words[0])
+		}
+	}
+
+	return tags
+}
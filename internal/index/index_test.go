@@ -0,0 +1,94 @@
+package index
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"duriny.envs.sh/twtr/internal/timeline"
+	"duriny.envs.sh/twtr/twtxt"
+	"github.com/google/go-cmp/cmp"
+)
+
+// parse is a helper to create a twtxt file from its lines.
+func parse(t *testing.T, lines ...string) *twtxt.File {
+	t.Helper()
+
+	file, err := twtxt.Parse(strings.NewReader(strings.Join(lines, "\n")))
+	if err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	return file
+}
+
+func TestIndex(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "twtr", "index")
+
+	idx, err := Open(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	if have := idx.Len(); have != 0 {
+		t.Fatalf("have %d Tweets in a new index, want 0", have)
+	}
+
+	alice := timeline.New("alice", "https://example.org/alice.txt", parse(t,
+		"2022-03-14T16:30:00Z\tDeployed v1.3.0 #release",
+		"2022-03-15T09:00:00Z\tFiat lux!",
+	).Tweets)
+
+	if have := idx.Add(alice); have != 2 {
+		t.Errorf("added %d Tweets, want 2", have)
+	}
+
+	// fetching the same feed again only adds the new Tweets
+	alice = append(alice, timeline.New("alice", "https://example.org/alice.txt", parse(t,
+		"2022-03-16T09:00:00Z\tRolled back v1.3.0",
+	).Tweets)...)
+
+	if have := idx.Add(alice); have != 1 {
+		t.Errorf("added %d Tweets, want 1", have)
+	}
+
+	if err := idx.Save(); err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	terms := idx.terms
+
+	idx, err = Open(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	// the postings are read back as they were saved, not rebuilt
+	if diff := cmp.Diff(terms, idx.terms); diff != "" {
+		t.Errorf("unexpected terms after reopening (-want +have):\n%s", diff)
+	}
+
+	if have := idx.Len(); have != 3 {
+		t.Errorf("have %d Tweets after reopening, want 3", have)
+	}
+
+	hash := alice[0].Hash()
+
+	doc := idx.Get(hash)
+	if doc == nil {
+		t.Fatalf("missing Tweet %s", hash)
+	}
+
+	if doc.Nick != "alice" || doc.Text != "Deployed v1.3.0 #release" {
+		t.Errorf("have %s %q, want alice %q", doc.Nick, doc.Text, "Deployed v1.3.0 #release")
+	}
+
+	docs, err := idx.Search("tag:release")
+	if err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	if len(docs) != 1 || docs[0].Hash != hash {
+		t.Errorf("have %d results for tag:release after reopening, want %s", len(docs), hash)
+	}
+}
@@ -0,0 +1,265 @@
+package index
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Query is a parsed search query, it matches the Tweets that match all of the
+// terms of any one of its clauses.
+//
+//     deploy "release notes"        both "deploy" and the phrase
+//     deploy OR rollback            either word
+//     from:alice tag:release        Tweets by alice with the #release hashtag
+//     url:https://example.org/twtxt.txt
+type Query [][]term
+
+// term is a single word, phrase, or field in a Query.
+type term struct {
+	field string
+	words []string
+}
+
+// fields are the field prefixes a term can have.
+var fields = map[string]bool{
+	"from": true,
+	"tag":  true,
+	"url":  true,
+}
+
+// ParseQuery parses a search query, terms are separated by whitespace and all
+// of them have to match, unless separated by OR. Quoted terms are phrases,
+// where every word has to match in the same order.
+func ParseQuery(q string) (Query, error) {
+	var (
+		query  Query
+		clause []term
+	)
+
+	tokens, err := split(q)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, token := range tokens {
+		if token == "OR" {
+			if len(clause) == 0 {
+				return nil, errors.New("OR is missing a search term")
+			}
+
+			query = append(query, clause)
+			clause = nil
+
+			continue
+		}
+
+		t, err := parseTerm(token)
+		if err != nil {
+			return nil, err
+		}
+
+		// a term that has no words, such as punctuation, matches anything
+		if len(t.words) == 0 {
+			continue
+		}
+
+		clause = append(clause, t)
+	}
+
+	if len(clause) == 0 {
+		if len(query) > 0 {
+			return nil, errors.New("OR is missing a search term")
+		}
+
+		return nil, errors.New("empty search query")
+	}
+
+	return append(query, clause), nil
+}
+
+// split is a helper to ParseQuery(), it splits the query on whitespace, except
+// inside double quotes, the quotes are kept so that phrases can be told apart.
+func split(q string) ([]string, error) {
+	var (
+		tokens []string
+		token  strings.Builder
+		quoted bool
+	)
+
+	for _, r := range q {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			token.WriteRune(r)
+		case !quoted && (r == ' ' || r == '\t' || r == '\n'):
+			if token.Len() > 0 {
+				tokens = append(tokens, token.String())
+				token.Reset()
+			}
+		default:
+			token.WriteRune(r)
+		}
+	}
+
+	if quoted {
+		return nil, errors.New("unterminated quote in search query")
+	}
+
+	if token.Len() > 0 {
+		tokens = append(tokens, token.String())
+	}
+
+	return tokens, nil
+}
+
+// parseTerm is a helper to ParseQuery(), it parses a single token of a query.
+func parseTerm(token string) (term, error) {
+	if i := strings.IndexByte(token, ':'); i > 0 && !strings.HasPrefix(token, `"`) {
+		field := strings.ToLower(token[:i])
+		value := strings.Trim(token[i+1:], `"`)
+
+		if !fields[field] {
+			return term{}, fmt.Errorf("unknown search field: %q", field)
+		}
+
+		if value == "" {
+			return term{}, fmt.Errorf("missing value for search field: %q", field)
+		}
+
+		switch field {
+		case "from":
+			value = strings.TrimPrefix(value, "@")
+		case "tag":
+			value = strings.TrimPrefix(value, "#")
+		}
+
+		return term{field: field, words: []string{strings.ToLower(value)}}, nil
+	}
+
+	return term{words: tokenize(strings.Trim(token, `"`))}, nil
+}
+
+// Search returns the indexed Tweets matching the query, newest first. See
+// ParseQuery for the query syntax.
+func (idx *Index) Search(q string) ([]*Doc, error) {
+	query, err := ParseQuery(q)
+	if err != nil {
+		return nil, err
+	}
+
+	return idx.Query(query), nil
+}
+
+// Query returns the indexed Tweets matching a parsed Query, newest first.
+func (idx *Index) Query(query Query) []*Doc {
+	matches := make(map[string]bool)
+
+	for _, clause := range query {
+		for hash := range idx.clause(clause) {
+			matches[hash] = true
+		}
+	}
+
+	docs := make([]*Doc, 0, len(matches))
+	for hash := range matches {
+		docs = append(docs, idx.docs[hash])
+	}
+
+	sort.Slice(docs, func(i, j int) bool {
+		if docs[i].Time.Equal(docs[j].Time) {
+			return docs[i].Hash < docs[j].Hash
+		}
+
+		return docs[i].Time.After(docs[j].Time)
+	})
+
+	return docs
+}
+
+// clause is a helper to Query(), it returns the hashes of the Tweets matching
+// every term of the clause.
+func (idx *Index) clause(clause []term) map[string]bool {
+	var matches map[string]bool
+
+	for _, t := range clause {
+		matches = intersect(matches, idx.term(t))
+
+		if len(matches) == 0 {
+			return nil
+		}
+	}
+
+	return matches
+}
+
+// term is a helper to clause(), it returns the hashes of the Tweets matching
+// a single term.
+func (idx *Index) term(t term) map[string]bool {
+	if t.field != "" {
+		return idx.terms[t.field+":"+t.words[0]]
+	}
+
+	var matches map[string]bool
+	for _, word := range t.words {
+		matches = intersect(matches, idx.terms[word])
+	}
+
+	if len(t.words) == 1 {
+		return matches
+	}
+
+	// phrases need the words in order, which the postings don't record
+	phrase := make(map[string]bool)
+
+	for hash := range matches {
+		if contains(tokenize(idx.docs[hash].Text), t.words) {
+			phrase[hash] = true
+		}
+	}
+
+	return phrase
+}
+
+// intersect is a helper to clause() and term(), it returns the hashes in both
+// a and b, where a nil a means no hashes have been seen yet.
+func intersect(a, b map[string]bool) map[string]bool {
+	if a == nil {
+		a = make(map[string]bool, len(b))
+		for hash := range b {
+			a[hash] = true
+		}
+
+		return a
+	}
+
+	for hash := range a {
+		if !b[hash] {
+			delete(a, hash)
+		}
+	}
+
+	return a
+}
+
+// contains is a helper to term(), it reports whether words contains phrase as
+// a run of consecutive words.
+func contains(words, phrase []string) bool {
+	for i := 0; i+len(phrase) <= len(words); i++ {
+		match := true
+
+		for j := range phrase {
+			if words[i+j] != phrase[j] {
+				match = false
+				break
+			}
+		}
+
+		if match {
+			return true
+		}
+	}
+
+	return false
+}
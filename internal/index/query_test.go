@@ -0,0 +1,172 @@
+package index
+
+import (
+	"path/filepath"
+	"testing"
+
+	"duriny.envs.sh/twtr/internal/timeline"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		want  Query
+		err   bool
+	}{
+		{
+			query: "deploy Production",
+			want:  Query{{{words: []string{"deploy"}}, {words: []string{"production"}}}},
+		},
+		{
+			query: `deploy OR "rollback notes"`,
+			want:  Query{{{words: []string{"deploy"}}}, {{words: []string{"rollback", "notes"}}}},
+		},
+		{
+			query: "from:@Alice tag:#release",
+			want:  Query{{{field: "from", words: []string{"alice"}}, {field: "tag", words: []string{"release"}}}},
+		},
+		{
+			query: "v1.3.0",
+			want:  Query{{{words: []string{"v1", "3", "0"}}}},
+		},
+		{
+			query: "",
+			err:   true,
+		},
+		{
+			query: "OR deploy",
+			err:   true,
+		},
+		{
+			query: "deploy OR",
+			err:   true,
+		},
+		{
+			query: `"deploy`,
+			err:   true,
+		},
+		{
+			query: "to:alice",
+			err:   true,
+		},
+		{
+			query: "from:",
+			err:   true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.query, func(t *testing.T) {
+			have, err := ParseQuery(test.query)
+
+			if (err != nil) != test.err {
+				t.Fatalf("err = %v, want error %t", err, test.err)
+			}
+
+			if diff := cmp.Diff(test.want, have, cmp.AllowUnexported(term{})); diff != "" {
+				t.Errorf("unexpected query (-want +have):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSearch(t *testing.T) {
+	idx := New(filepath.Join(t.TempDir(), "index"))
+
+	idx.Add(timeline.New("alice", "https://example.org/alice.txt", parse(t,
+		"2022-02-28T09:00:00Z\tDeployed v1.2.0 to staging",
+		"2022-03-14T16:30:00Z\tDeployed v1.3.0 to production, rollback notes in the wiki #release",
+		"2022-04-01T12:00:00Z\tNo deploys today, it's a holiday",
+	).Tweets))
+
+	idx.Add(timeline.New("bob", "https://example.org/bob.txt", parse(t,
+		"2022-03-02T10:00:00Z\tdeployed the new database",
+		"2022-03-20T10:00:00Z\tNotes on the rollback #Release",
+	).Tweets))
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{
+			query: "deployed",
+			want: []string{
+				"Deployed v1.3.0 to production, rollback notes in the wiki #release",
+				"deployed the new database",
+				"Deployed v1.2.0 to staging",
+			},
+		},
+		{
+			query: "deployed staging",
+			want:  []string{"Deployed v1.2.0 to staging"},
+		},
+		{
+			query: "staging OR database",
+			want: []string{
+				"deployed the new database",
+				"Deployed v1.2.0 to staging",
+			},
+		},
+		{
+			query: `"rollback notes"`,
+			want:  []string{"Deployed v1.3.0 to production, rollback notes in the wiki #release"},
+		},
+		{
+			query: `"notes rollback"`,
+			want:  []string{},
+		},
+		{
+			query: "tag:release",
+			want: []string{
+				"Notes on the rollback #Release",
+				"Deployed v1.3.0 to production, rollback notes in the wiki #release",
+			},
+		},
+		{
+			query: "from:bob tag:release",
+			want:  []string{"Notes on the rollback #Release"},
+		},
+		{
+			query: "url:https://example.org/bob.txt deployed",
+			want:  []string{"deployed the new database"},
+		},
+		{
+			query: "holiday OR from:bob database",
+			want: []string{
+				"No deploys today, it's a holiday",
+				"deployed the new database",
+			},
+		},
+		{
+			query: "v1.3.0",
+			want:  []string{"Deployed v1.3.0 to production, rollback notes in the wiki #release"},
+		},
+		{
+			query: "kubernetes",
+			want:  []string{},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.query, func(t *testing.T) {
+			docs, err := idx.Search(test.query)
+			if err != nil {
+				t.Fatalf("unexpected error: %q", err)
+			}
+
+			have := make([]string, 0, len(docs))
+			for _, doc := range docs {
+				have = append(have, doc.Text)
+			}
+
+			if diff := cmp.Diff(test.want, have); diff != "" {
+				t.Errorf("unexpected results (-want +have):\n%s", diff)
+			}
+		})
+	}
+}
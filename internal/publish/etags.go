@@ -0,0 +1,68 @@
+package publish
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+
+	"duriny.envs.sh/twtr/internal/atomicfile"
+)
+
+// anyETag is kept as the ETag of an upload when the server did not return one,
+// so that the next upload still expects the file to exist, but can't check if
+// it was changed in the meantime.
+const anyETag = "*"
+
+// ETags are the ETags of the last upload to each url, they are saved to a file
+// between runs, so that each upload can check that the published twtfile was
+// not changed since.
+type ETags struct {
+	path string
+	tags map[string]string
+}
+
+// OpenETags reads the ETags saved in the file at path, if there is no such file
+// then nothing has been published yet.
+func OpenETags(path string) (*ETags, error) {
+	e := &ETags{
+		path: path,
+		tags: make(map[string]string),
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return e, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &e.tags); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// Get returns the ETag of the last upload to the url, or an empty string if
+// nothing was uploaded to the url yet.
+func (e *ETags) Get(url string) string {
+	return e.tags[url]
+}
+
+// Set records the ETag of an upload to the url.
+func (e *ETags) Set(url, etag string) {
+	e.tags[url] = etag
+}
+
+// Save writes the ETags to their file, see atomicfile.WriteFile.
+func (e *ETags) Save() error {
+	data, err := json.Marshal(e.tags)
+	if err != nil {
+		return err
+	}
+
+	return atomicfile.WriteFile(e.path, data, 0o600)
+}
@@ -0,0 +1,179 @@
+package publish
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"duriny.envs.sh/twtr/twtxt/config"
+)
+
+// DefaultGistAPI is the base url of the GitHub REST API.
+const DefaultGistAPI = "https://api.github.com"
+
+// DefaultGistFile is the name of the twtfile in a gist.
+const DefaultGistFile = "twtxt.txt"
+
+// Gist hosts the twtfile as a file in a GitHub gist, using the Gist REST API.
+type Gist struct {
+	ID     string
+	File   string
+	Token  string
+	APIURL string
+	Client *http.Client
+}
+
+// NewGist creates a Gist publisher from the [gist] section of the config,
+// reading the token from its file. The ID may be empty if the gist has not been
+// created yet.
+func NewGist(cfg config.Gist) (*Gist, error) {
+	gist := &Gist{
+		ID:     cfg.ID,
+		File:   cfg.File,
+		APIURL: cfg.APIURL,
+		Client: http.DefaultClient,
+	}
+
+	if gist.File == "" {
+		gist.File = DefaultGistFile
+	}
+
+	if gist.APIURL == "" {
+		gist.APIURL = DefaultGistAPI
+	}
+
+	if cfg.TokenFile == "" {
+		return nil, errors.New("gist: missing token_file")
+	}
+
+	token, err := os.ReadFile(cfg.TokenFile)
+	if err != nil {
+		return nil, fmt.Errorf("gist: %w", err)
+	}
+
+	gist.Token = strings.TrimSpace(string(token))
+
+	return gist, nil
+}
+
+// gistFile is a file in the request and response bodies of the Gist API.
+type gistFile struct {
+	Content string `json:"content,omitempty"`
+	RawURL  string `json:"raw_url,omitempty"`
+}
+
+// gistBody is the request and response body of the Gist API.
+type gistBody struct {
+	ID          string              `json:"id,omitempty"`
+	Description string              `json:"description,omitempty"`
+	Public      *bool               `json:"public,omitempty"`
+	Files       map[string]gistFile `json:"files"`
+}
+
+// Create creates a new public gist containing the twtfile, and sets the ID of
+// the Gist. Returns the raw url of the twtfile, which is suitable as a twturl
+// as it always points to the latest revision of the gist.
+func (gist *Gist) Create(ctx context.Context, twtfile []byte) (string, error) {
+	public := true
+
+	resp, err := gist.do(ctx, http.MethodPost, "/gists", gistBody{
+		Description: "twtxt feed",
+		Public:      &public,
+		Files:       map[string]gistFile{gist.File: {Content: string(twtfile)}},
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	file, ok := resp.Files[gist.File]
+	if resp.ID == "" || !ok || file.RawURL == "" {
+		return "", errors.New("gist: unexpected response")
+	}
+
+	gist.ID = resp.ID
+
+	return latest(file.RawURL), nil
+}
+
+// Publish updates the twtfile in the gist.
+func (gist *Gist) Publish(ctx context.Context, twtfile []byte) error {
+	if gist.ID == "" {
+		return errors.New("gist: missing id")
+	}
+
+	_, err := gist.do(ctx, http.MethodPatch, "/gists/"+gist.ID, gistBody{
+		Files: map[string]gistFile{gist.File: {Content: string(twtfile)}},
+	})
+
+	return err
+}
+
+// do is a helper to Create() and Publish(), it makes an authenticated request
+// to the Gist API.
+func (gist *Gist) do(ctx context.Context, method, path string, body gistBody) (*gistBody, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	url := strings.TrimSuffix(gist.APIURL, "/") + path
+
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "token "+gist.Token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := gist.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+
+		if json.NewDecoder(resp.Body).Decode(&apiErr) == nil && apiErr.Message != "" {
+			return nil, fmt.Errorf("gist: %s: %s", resp.Status, apiErr.Message)
+		}
+
+		return nil, fmt.Errorf("gist: %s", resp.Status)
+	}
+
+	var result gistBody
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("gist: %w", err)
+	}
+
+	return &result, nil
+}
+
+// latest is a helper to Create(), it removes the revision from the raw url of
+// a gist file, so that the url always points to the latest revision.
+//
+//     https://gist.githubusercontent.com/<user>/<id>/raw/<revision>/<file>
+func latest(rawURL string) string {
+	i := strings.Index(rawURL, "/raw/")
+	if i < 0 {
+		return rawURL
+	}
+
+	parts := strings.SplitN(rawURL[i+len("/raw/"):], "/", 2)
+	if len(parts) != 2 {
+		return rawURL
+	}
+
+	return rawURL[:i] + "/raw/" + parts[1]
+}
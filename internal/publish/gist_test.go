@@ -0,0 +1,130 @@
+package publish
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"duriny.envs.sh/twtr/twtxt/config"
+)
+
+// gistServer is a stub of the Gist API that stores a single gist.
+type gistServer struct {
+	id    string
+	files map[string]string
+}
+
+func (s *gistServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "token ghp_secret" {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(map[string]string{"message": "Bad credentials"})
+
+		return
+	}
+
+	var body gistBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/api/v3/gists":
+		s.id = "aa5a315d61ae9438b18d"
+		s.files = make(map[string]string)
+	case r.Method == http.MethodPatch && r.URL.Path == "/api/v3/gists/"+s.id && s.id != "":
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"message": "Not Found"})
+
+		return
+	}
+
+	resp := gistBody{ID: s.id, Files: make(map[string]gistFile)}
+
+	for name, file := range body.Files {
+		s.files[name] = file.Content
+	}
+
+	for name := range s.files {
+		resp.Files[name] = gistFile{
+			RawURL: "https://gist.githubusercontent.com/alice/" + s.id + "/raw/3b1c5f6d/" + name,
+		}
+	}
+
+	json.NewEncoder(w).Encode(resp)
+}
+
+func TestNewGist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gist-token")
+	if err := os.WriteFile(path, []byte("ghp_secret\n"), 0o600); err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	gist, err := NewGist(config.Gist{TokenFile: path})
+	if err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	if gist.Token != "ghp_secret" || gist.File != DefaultGistFile || gist.APIURL != DefaultGistAPI {
+		t.Errorf("have token %q, file %q, api %q", gist.Token, gist.File, gist.APIURL)
+	}
+
+	if _, err := NewGist(config.Gist{}); err == nil {
+		t.Error("missing error for a missing token file")
+	}
+}
+
+func TestGist(t *testing.T) {
+	stub := &gistServer{}
+
+	ts := httptest.NewServer(stub)
+	defer ts.Close()
+
+	gist := &Gist{
+		File:   "alice.txt",
+		Token:  "ghp_secret",
+		APIURL: ts.URL + "/api/v3/",
+		Client: ts.Client(),
+	}
+
+	ctx := context.Background()
+
+	if err := gist.Publish(ctx, []byte("too soon")); err == nil {
+		t.Error("missing error for publishing without a gist id")
+	}
+
+	twturl, err := gist.Create(ctx, []byte("# nick = alice\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	if want := "https://gist.githubusercontent.com/alice/aa5a315d61ae9438b18d/raw/alice.txt"; twturl != want {
+		t.Errorf("have twturl %q, want %q", twturl, want)
+	}
+
+	if gist.ID != "aa5a315d61ae9438b18d" {
+		t.Errorf("have gist id %q, want %q", gist.ID, "aa5a315d61ae9438b18d")
+	}
+
+	twtfile := "# nick = alice\n2016-02-04T13:30:00+01:00\tFiat lux!\n"
+
+	if err := gist.Publish(ctx, []byte(twtfile)); err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	if have := stub.files["alice.txt"]; have != twtfile {
+		t.Errorf("have gist file %q, want %q", have, twtfile)
+	}
+
+	gist.Token = "ghp_wrong"
+
+	err = gist.Publish(ctx, []byte(twtfile))
+	if want := "gist: 401 Unauthorized: Bad credentials"; err == nil || err.Error() != want {
+		t.Errorf("have error %v, want %q", err, want)
+	}
+}
@@ -0,0 +1,219 @@
+package publish
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"duriny.envs.sh/twtr/twtxt/config"
+)
+
+// ErrConflict is returned when the published twtfile was changed by someone
+// else since it was last uploaded, so uploading would overwrite their edits.
+var ErrConflict = errors.New("twtfile was changed since it was last published")
+
+// DefaultRetries is the number of times a failed upload is retried, when the
+// config does not say otherwise.
+const DefaultRetries = 3
+
+// WebDAV uploads the twtfile with an HTTP PUT, as supported by WebDAV servers
+// and many other web servers.
+type WebDAV struct {
+	URL      string
+	Username string
+	Password string
+
+	// Retries is the number of times a failed upload is retried, and Backoff
+	// is the delay before the first retry, it doubles with each retry.
+	Retries int
+	Backoff time.Duration
+
+	Client *http.Client
+}
+
+// NewWebDAV creates a WebDAV publisher from the [publish] section of the
+// config, reading the password from its file.
+func NewWebDAV(cfg config.Publish) (*WebDAV, error) {
+	if cfg.URL == "" {
+		return nil, errors.New("publish: missing url")
+	}
+
+	dav := &WebDAV{
+		URL:      cfg.URL,
+		Username: cfg.Username,
+		Retries:  cfg.Retries,
+		Backoff:  time.Second,
+		Client:   http.DefaultClient,
+	}
+
+	switch {
+	case dav.Retries == 0:
+		dav.Retries = DefaultRetries
+	case dav.Retries < 0:
+		dav.Retries = 0
+	}
+
+	if cfg.PasswordFile != "" {
+		password, err := os.ReadFile(cfg.PasswordFile)
+		if err != nil {
+			return nil, fmt.Errorf("publish: %w", err)
+		}
+
+		dav.Password = strings.TrimSpace(string(password))
+	}
+
+	return dav, nil
+}
+
+// Publish uploads the twtfile, returning the ETag of the uploaded file, or an
+// empty string if the server did not return one. The etag is the ETag returned
+// by the last upload, the upload only succeeds if the published file has not
+// changed since, otherwise ErrConflict is returned. An empty etag means nothing
+// was uploaded yet, so the upload only succeeds if there is no published file.
+//
+// Network errors and server errors are retried, other errors are not.
+func (dav *WebDAV) Publish(ctx context.Context, twtfile []byte, etag string) (string, error) {
+	backoff := dav.Backoff
+
+	for attempt := 0; ; attempt++ {
+		tag, err := dav.put(ctx, twtfile, etag)
+
+		var retry *retryable
+		if !errors.As(err, &retry) || attempt >= dav.Retries {
+			if retry != nil {
+				err = retry.err
+			}
+
+			return tag, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+	}
+}
+
+// Upload uploads the twtfile like Publish, with the ETag of the last upload to
+// the url kept in etags, which are saved once the twtfile is published. When
+// the server does not return an ETag, the next upload only checks that the
+// published file still exists. When nothing was uploaded to the url yet, the
+// file that is already published there, if any, is replaced, see ETag.
+func (dav *WebDAV) Upload(ctx context.Context, twtfile []byte, etags *ETags) error {
+	etag := etags.Get(dav.URL)
+	if etag == "" {
+		var err error
+
+		if etag, err = dav.ETag(ctx); err != nil {
+			return err
+		}
+	}
+
+	tag, err := dav.Publish(ctx, twtfile, etag)
+	if err != nil {
+		return err
+	}
+
+	if tag == "" {
+		tag = anyETag
+	}
+
+	etags.Set(dav.URL, tag)
+
+	return etags.Save()
+}
+
+// ETag returns the ETag of the published file, so that it can be replaced by
+// the first upload, or an empty string if there is no published file. When the
+// server does not return an ETag for the file, the upload only checks that the
+// file still exists.
+func (dav *WebDAV) ETag(ctx context.Context) (string, error) {
+	req, err := dav.request(ctx, http.MethodHead, nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := dav.Client.Do(req)
+	if err != nil {
+		return "", err
+	}
+
+	resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return "", nil
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return "", fmt.Errorf("publish: %s", resp.Status)
+	}
+
+	if tag := resp.Header.Get("ETag"); tag != "" {
+		return tag, nil
+	}
+
+	return anyETag, nil
+}
+
+// put is a helper to Publish(), it makes a single upload attempt.
+func (dav *WebDAV) put(ctx context.Context, twtfile []byte, etag string) (string, error) {
+	req, err := dav.request(ctx, http.MethodPut, twtfile)
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+
+	if etag != "" {
+		req.Header.Set("If-Match", etag)
+	} else {
+		req.Header.Set("If-None-Match", "*")
+	}
+
+	resp, err := dav.Client.Do(req)
+	if err != nil {
+		return "", &retryable{err}
+	}
+
+	resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusPreconditionFailed:
+		return "", ErrConflict
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return "", &retryable{fmt.Errorf("publish: %s", resp.Status)}
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return "", fmt.Errorf("publish: %s", resp.Status)
+	}
+
+	return resp.Header.Get("ETag"), nil
+}
+
+// request is a helper to put() and ETag(), it creates an authenticated request.
+func (dav *WebDAV) request(ctx context.Context, method string, body []byte) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, dav.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	if dav.Username != "" || dav.Password != "" {
+		req.SetBasicAuth(dav.Username, dav.Password)
+	}
+
+	return req, nil
+}
+
+// retryable wraps an error from an upload that may succeed if retried.
+type retryable struct {
+	err error
+}
+
+func (r *retryable) Error() string { return r.err.Error() }
+func (r *retryable) Unwrap() error { return r.err }
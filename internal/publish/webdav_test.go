@@ -0,0 +1,356 @@
+package publish
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"duriny.envs.sh/twtr/twtxt/config"
+)
+
+// davServer is a minimal WebDAV server that stores a single file.
+type davServer struct {
+	mu       sync.Mutex
+	body     string
+	version  int
+	failures int  // the number of requests to fail before succeeding
+	noETag   bool // don't return the ETag of an upload
+	puts     int
+}
+
+func (s *davServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if user, pass, ok := r.BasicAuth(); !ok || user != "alice" || pass != "hunter2" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if s.failures > 0 {
+		s.failures--
+		w.WriteHeader(http.StatusServiceUnavailable)
+
+		return
+	}
+
+	etag := s.etag()
+
+	switch r.Method {
+	case http.MethodHead:
+		if s.version == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if !s.noETag {
+			w.Header().Set("ETag", etag)
+		}
+
+		w.WriteHeader(http.StatusOK)
+	case http.MethodPut:
+		s.puts++
+
+		exists := s.version > 0
+		match, noneMatch := r.Header.Get("If-Match"), r.Header.Get("If-None-Match")
+
+		if (match == "*" && !exists) || (match != "" && match != "*" && match != etag) || (noneMatch == "*" && exists) {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		s.body = string(body)
+		s.version++
+
+		if !s.noETag {
+			w.Header().Set("ETag", fmt.Sprintf(`"%d"`, s.version))
+		}
+
+		w.WriteHeader(http.StatusCreated)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestNewWebDAV(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dav-password")
+	if err := os.WriteFile(path, []byte("hunter2\n"), 0o600); err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	dav, err := NewWebDAV(config.Publish{
+		URL:          "https://dav.example.org/alice/twtxt.txt",
+		Username:     "alice",
+		PasswordFile: path,
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	if dav.Password != "hunter2" {
+		t.Errorf("have password %q, want %q", dav.Password, "hunter2")
+	}
+
+	if dav.Retries != DefaultRetries {
+		t.Errorf("have %d retries, want %d", dav.Retries, DefaultRetries)
+	}
+
+	if dav, _ := NewWebDAV(config.Publish{URL: "https://dav.example.org/", Retries: -1}); dav.Retries != 0 {
+		t.Errorf("have %d retries, want 0", dav.Retries)
+	}
+
+	if _, err := NewWebDAV(config.Publish{}); err == nil {
+		t.Error("missing error for a missing url")
+	}
+
+	if _, err := NewWebDAV(config.Publish{URL: "https://dav.example.org/", PasswordFile: path + ".missing"}); err == nil {
+		t.Error("missing error for a missing password file")
+	}
+}
+
+// etag returns the ETag of the current version of the file.
+func (s *davServer) etag() string {
+	return fmt.Sprintf(`"%d"`, s.version)
+}
+
+func TestWebDAVPublish(t *testing.T) {
+	tests := []struct {
+		name     string
+		server   *davServer
+		password string
+		retries  int
+		etag     string
+		want     string
+		err      bool
+		conflict bool
+		puts     int
+	}{
+		{
+			name:     "FirstUpload",
+			server:   &davServer{},
+			password: "hunter2",
+			want:     `"1"`,
+			puts:     1,
+		},
+		{
+			name:     "MatchingETag",
+			server:   &davServer{version: 4},
+			password: "hunter2",
+			etag:     `"4"`,
+			want:     `"5"`,
+			puts:     1,
+		},
+		{
+			name:     "ConcurrentEdit",
+			server:   &davServer{version: 5},
+			password: "hunter2",
+			etag:     `"4"`,
+			conflict: true,
+			err:      true,
+			puts:     1,
+		},
+		{
+			name:     "AlreadyPublished",
+			server:   &davServer{version: 3},
+			password: "hunter2",
+			conflict: true,
+			err:      true,
+			puts:     1,
+		},
+		{
+			name:     "NoETagOnUpload",
+			server:   &davServer{noETag: true},
+			password: "hunter2",
+			want:     "",
+			puts:     1,
+		},
+		{
+			name:     "AnyETag",
+			server:   &davServer{version: 2},
+			password: "hunter2",
+			etag:     "*",
+			want:     `"3"`,
+			puts:     1,
+		},
+		{
+			name:     "Retried",
+			server:   &davServer{failures: 2},
+			password: "hunter2",
+			retries:  3,
+			want:     `"1"`,
+			puts:     1,
+		},
+		{
+			name:     "TooManyFailures",
+			server:   &davServer{failures: 3},
+			password: "hunter2",
+			retries:  2,
+			err:      true,
+		},
+		{
+			name:     "Unauthorized",
+			server:   &davServer{},
+			password: "wrong",
+			retries:  3,
+			err:      true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			ts := httptest.NewServer(test.server)
+			defer ts.Close()
+
+			dav := &WebDAV{
+				URL:      ts.URL + "/alice/twtxt.txt",
+				Username: "alice",
+				Password: test.password,
+				Retries:  test.retries,
+				Client:   ts.Client(),
+			}
+
+			have, err := dav.Publish(context.Background(), []byte("2016-02-04T13:30:00+01:00\tFiat lux!\n"), test.etag)
+
+			if (err != nil) != test.err {
+				t.Fatalf("err = %v, want error %t", err, test.err)
+			}
+
+			if have := errors.Is(err, ErrConflict); have != test.conflict {
+				t.Errorf("conflict = %t, want %t", have, test.conflict)
+			}
+
+			if have != test.want {
+				t.Errorf("have ETag %q, want %q", have, test.want)
+			}
+
+			if have := test.server.puts; have != test.puts {
+				t.Errorf("have %d uploads, want %d", have, test.puts)
+			}
+
+			if err == nil && test.server.body != "2016-02-04T13:30:00+01:00\tFiat lux!\n" {
+				t.Errorf("have uploaded %q", test.server.body)
+			}
+		})
+	}
+}
+
+func TestWebDAVUpload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "etags.json")
+
+	server := &davServer{}
+
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	dav := &WebDAV{
+		URL:      ts.URL + "/alice/twtxt.txt",
+		Username: "alice",
+		Password: "hunter2",
+		Client:   ts.Client(),
+	}
+
+	upload := func() error {
+		etags, err := OpenETags(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %q", err)
+		}
+
+		return dav.Upload(context.Background(), []byte("2016-02-04T13:30:00+01:00\tFiat lux!\n"), etags)
+	}
+
+	// a twtfile that is already published is replaced by the first upload
+	server.version = 3
+
+	// the ETag of each upload is saved for the next run
+	for i := 0; i < 2; i++ {
+		if err := upload(); err != nil {
+			t.Fatalf("unexpected error: %q", err)
+		}
+	}
+
+	// someone else changes the twtfile
+	server.version++
+
+	if err := upload(); !errors.Is(err, ErrConflict) {
+		t.Errorf("have error %v, want ErrConflict", err)
+	}
+
+	// a server without ETags can still be published to
+	etags, err := OpenETags(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	server.noETag = true
+	etags.Set(dav.URL, server.etag())
+
+	for i := 0; i < 2; i++ {
+		if err := dav.Upload(context.Background(), []byte("2016-02-04T13:30:00+01:00\tFiat lux!\n"), etags); err != nil {
+			t.Fatalf("unexpected error: %q", err)
+		}
+	}
+
+	if have := etags.Get(dav.URL); have != "*" {
+		t.Errorf("have ETag %q, want %q", have, "*")
+	}
+}
+
+func TestWebDAVETag(t *testing.T) {
+	tests := []struct {
+		name   string
+		server *davServer
+		want   string
+	}{
+		{
+			name:   "NotPublished",
+			server: &davServer{},
+			want:   "",
+		},
+		{
+			name:   "Published",
+			server: &davServer{version: 3},
+			want:   `"3"`,
+		},
+		{
+			name:   "NoETag",
+			server: &davServer{version: 3, noETag: true},
+			want:   "*",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			ts := httptest.NewServer(test.server)
+			defer ts.Close()
+
+			dav := &WebDAV{
+				URL:      ts.URL + "/alice/twtxt.txt",
+				Username: "alice",
+				Password: "hunter2",
+				Client:   ts.Client(),
+			}
+
+			have, err := dav.ETag(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %q", err)
+			}
+
+			if have != test.want {
+				t.Errorf("have ETag %q, want %q", have, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ANSI escape sequences used to style the rendered Markdown.
+const (
+	bold       = "\x1b[1m"
+	boldOff    = "\x1b[22m"
+	italic     = "\x1b[3m"
+	italicOff  = "\x1b[23m"
+	underline  = "\x1b[4m"
+	underOff   = "\x1b[24m"
+	reverse    = "\x1b[7m"
+	reverseOff = "\x1b[27m"
+)
+
+// escapable are the characters that can be escaped with a backslash to prevent
+// them being treated as Markdown.
+const escapable = "\\`*_[]()!#"
+
+// Renderer turns the subset of Markdown used by Yarn.Social clients into text
+// that is suitable for a terminal.
+//
+// The supported subset is links [text](url), images ![alt](url), **bold**,
+// *emphasis*, and `inline code`. Links and images are replaced by their text
+// and a numbered footnote containing the url.
+type Renderer struct {
+	// ANSI enables styling the rendered text with ANSI escape sequences,
+	// otherwise the Markdown is rendered as plain text.
+	ANSI bool
+}
+
+// Markdown renders the Markdown text, any links or images are listed as
+// footnotes after the text.
+func (r Renderer) Markdown(text string) string {
+	var footnotes []string
+
+	rendered := r.render(text, &footnotes)
+
+	if len(footnotes) == 0 {
+		return rendered
+	}
+
+	lines := make([]string, len(footnotes))
+	for i, url := range footnotes {
+		lines[i] = fmt.Sprintf("[%d]: %s", i+1, url)
+	}
+
+	return rendered + "\n\n" + strings.Join(lines, "\n")
+}
+
+// render is a helper to Markdown(), it renders the text, adding any link urls
+// to the footnotes.
+func (r Renderer) render(text string, footnotes *[]string) string {
+	var out strings.Builder
+
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		rest := text[i:]
+
+		switch {
+		// escaped characters are always literal
+		case c == '\\' && i+1 < len(text) && strings.IndexByte(escapable, text[i+1]) >= 0:
+			out.WriteByte(text[i+1])
+			i++
+			continue
+
+		// inline code is literal
+		case c == '`':
+			if end := strings.IndexByte(rest[1:], '`'); end > 0 {
+				out.WriteString(r.style(rest[1:end+1], reverse, reverseOff))
+				i += end + 1
+				continue
+			}
+
+		// images are shown by their alt text
+		case c == '!' && strings.HasPrefix(rest, "!["):
+			if alt, url, n, ok := parseLink(rest[1:]); ok {
+				label := "image"
+				if alt != "" {
+					label += ": " + r.render(alt, footnotes)
+				}
+
+				out.WriteString(r.style("["+label+"]", italic, italicOff))
+				out.WriteString(footnote(url, footnotes))
+				i += n
+				continue
+			}
+
+		// links are shown by their text
+		case c == '[':
+			if label, url, n, ok := parseLink(rest); ok {
+				if label == "" || label == url {
+					out.WriteString(r.style(url, underline, underOff))
+				} else {
+					out.WriteString(r.style(r.render(label, footnotes), underline, underOff))
+					out.WriteString(footnote(url, footnotes))
+				}
+
+				i += n - 1
+				continue
+			}
+
+		// strong emphasis
+		case strings.HasPrefix(rest, "**"):
+			if end := strings.Index(rest[2:], "**"); end > 0 {
+				out.WriteString(r.style(r.render(rest[2:end+2], footnotes), bold, boldOff))
+				i += end + 3
+				continue
+			}
+
+		// emphasis, but not a list bullet or a lone asterisk
+		case c == '*' && i+1 < len(text) && text[i+1] != ' ':
+			if end := strings.IndexByte(rest[1:], '*'); end > 0 && rest[end] != ' ' {
+				out.WriteString(r.style(r.render(rest[1:end+1], footnotes), italic, italicOff))
+				i += end + 1
+				continue
+			}
+		}
+
+		out.WriteByte(c)
+	}
+
+	return out.String()
+}
+
+// style is a helper to render(), it wraps the text in the ANSI escape
+// sequences, if ANSI styling is enabled.
+func (r Renderer) style(text, on, off string) string {
+	if !r.ANSI {
+		return text
+	}
+
+	return on + text + off
+}
+
+// parseLink is a helper to render(), it parses a link of the form [text](url)
+// at the start of s, and returns the text, the url, and the length of the link
+// in s. Reports false if s doesn't start with a link.
+func parseLink(s string) (text, url string, n int, ok bool) {
+	depth := 0
+
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '[':
+			depth++
+		case ']':
+			if depth--; depth > 0 {
+				continue
+			}
+
+			// the text has to be followed by the url
+			if !strings.HasPrefix(s[i+1:], "(") {
+				return "", "", 0, false
+			}
+
+			end := strings.IndexByte(s[i+2:], ')')
+			if end < 0 {
+				return "", "", 0, false
+			}
+
+			url = strings.TrimSpace(s[i+2 : i+2+end])
+			if url == "" || strings.ContainsAny(url, " \t\n") {
+				return "", "", 0, false
+			}
+
+			return s[1:i], url, i + 3 + end, true
+		}
+	}
+
+	return "", "", 0, false
+}
+
+// footnote is a helper to render(), it returns the footnote marker for the
+// url, adding the url to the footnotes if it isn't already there.
+func footnote(url string, footnotes *[]string) string {
+	for i, existing := range *footnotes {
+		if existing == url {
+			return fmt.Sprintf("[%d]", i+1)
+		}
+	}
+
+	*footnotes = append(*footnotes, url)
+
+	return fmt.Sprintf("[%d]", len(*footnotes))
+}
@@ -0,0 +1,131 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMarkdown(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		plain string
+		ansi  string
+	}{
+		{
+			name:  "Empty",
+			text:  "",
+			plain: "",
+			ansi:  "",
+		},
+		{
+			name:  "PlainText",
+			text:  "You can really go crazy here! ┐(ﾟ∀ﾟ)┌",
+			plain: "You can really go crazy here! ┐(ﾟ∀ﾟ)┌",
+			ansi:  "You can really go crazy here! ┐(ﾟ∀ﾟ)┌",
+		},
+		{
+			name:  "Mention",
+			text:  "@<example http://example.org/twtxt.txt> welcome to twtxt!",
+			plain: "@<example http://example.org/twtxt.txt> welcome to twtxt!",
+			ansi:  "@<example http://example.org/twtxt.txt> welcome to twtxt!",
+		},
+		{
+			name:  "Bold",
+			text:  "this is **important**",
+			plain: "this is important",
+			ansi:  "this is \x1b[1mimportant\x1b[22m",
+		},
+		{
+			name:  "Emphasis",
+			text:  "this is *subtle*",
+			plain: "this is subtle",
+			ansi:  "this is \x1b[3msubtle\x1b[23m",
+		},
+		{
+			name:  "Code",
+			text:  "run `go test ./...` first",
+			plain: "run go test ./... first",
+			ansi:  "run \x1b[7mgo test ./...\x1b[27m first",
+		},
+		{
+			name:  "CodeIsLiteral",
+			text:  "`**not bold**`",
+			plain: "**not bold**",
+			ansi:  "\x1b[7m**not bold**\x1b[27m",
+		},
+		{
+			name:  "Link",
+			text:  "see [the spec](https://twtxt.readthedocs.io) for details",
+			plain: "see the spec[1] for details\n\n[1]: https://twtxt.readthedocs.io",
+			ansi:  "see \x1b[4mthe spec\x1b[24m[1] for details\n\n[1]: https://twtxt.readthedocs.io",
+		},
+		{
+			name:  "LinkToItself",
+			text:  "[https://example.org](https://example.org)",
+			plain: "https://example.org",
+			ansi:  "\x1b[4mhttps://example.org\x1b[24m",
+		},
+		{
+			name:  "Image",
+			text:  "look ![a cat](https://example.org/cat.png)",
+			plain: "look [image: a cat][1]\n\n[1]: https://example.org/cat.png",
+			ansi:  "look \x1b[3m[image: a cat]\x1b[23m[1]\n\n[1]: https://example.org/cat.png",
+		},
+		{
+			name:  "ImageWithoutAlt",
+			text:  "![](https://example.org/cat.png)",
+			plain: "[image][1]\n\n[1]: https://example.org/cat.png",
+			ansi:  "\x1b[3m[image]\x1b[23m[1]\n\n[1]: https://example.org/cat.png",
+		},
+		{
+			name:  "MultipleLinks",
+			text:  "[a](https://a.example.org), [b](https://b.example.org) and [a again](https://a.example.org)",
+			plain: "a[1], b[2] and a again[1]\n\n[1]: https://a.example.org\n[2]: https://b.example.org",
+			ansi:  "\x1b[4ma\x1b[24m[1], \x1b[4mb\x1b[24m[2] and \x1b[4ma again\x1b[24m[1]\n\n[1]: https://a.example.org\n[2]: https://b.example.org",
+		},
+		{
+			name:  "NestedStyles",
+			text:  "**[bold link](https://example.org)**",
+			plain: "bold link[1]\n\n[1]: https://example.org",
+			ansi:  "\x1b[1m\x1b[4mbold link\x1b[24m[1]\x1b[22m\n\n[1]: https://example.org",
+		},
+		{
+			name:  "Escaped",
+			text:  "\\*not emphasis\\* and \\[not a link\\](https://example.org)",
+			plain: "*not emphasis* and [not a link](https://example.org)",
+			ansi:  "*not emphasis* and [not a link](https://example.org)",
+		},
+		{
+			name:  "Unclosed",
+			text:  "2 * 3 = 6, **unclosed, `unclosed, [unclosed](",
+			plain: "2 * 3 = 6, **unclosed, `unclosed, [unclosed](",
+			ansi:  "2 * 3 = 6, **unclosed, `unclosed, [unclosed](",
+		},
+		{
+			name:  "Multiline",
+			text:  "first line\n*second* line",
+			plain: "first line\nsecond line",
+			ansi:  "first line\n\x1b[3msecond\x1b[23m line",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Run("Plain", func(t *testing.T) {
+				if have, want := (Renderer{}).Markdown(test.text), test.plain; have != want {
+					t.Errorf("diff:\n%s", cmp.Diff(have, want))
+				}
+			})
+
+			t.Run("ANSI", func(t *testing.T) {
+				if have, want := (Renderer{ANSI: true}).Markdown(test.text), test.ansi; have != want {
+					t.Errorf("diff:\n%s", cmp.Diff(have, want))
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"duriny.envs.sh/twtr/twtxt/config"
+)
+
+// Style is a list of ANSI SGR parameters, e.g. "1;34" for bold blue text. The
+// zero Style leaves text unstyled.
+type Style string
+
+// attributes are the named text attributes that can be used in a style.
+var attributes = map[string]string{
+	"bold":      "1",
+	"dim":       "2",
+	"italic":    "3",
+	"underline": "4",
+	"blink":     "5",
+	"reverse":   "7",
+}
+
+// colors are the named colors that can be used in a style, each color can also
+// be prefixed with "bright-" to use the bright variant.
+var colors = map[string]int{
+	"black":   0,
+	"red":     1,
+	"green":   2,
+	"yellow":  3,
+	"blue":    4,
+	"magenta": 5,
+	"cyan":    6,
+	"white":   7,
+}
+
+// ParseStyle parses a style from a space separated list of attributes and
+// colors, as used in the [colors] section of the config, e.g. "bold blue".
+//
+// The attributes are bold, dim, italic, underline, blink, and reverse. The
+// colors are black, red, green, yellow, blue, magenta, cyan, and white, or any
+// of these prefixed with "bright-", or a number from 0 to 255 for one of the
+// 256 extended colors. The special style "none" is unstyled.
+func ParseStyle(spec string) (Style, error) {
+	var params []string
+
+	for _, word := range strings.Fields(strings.ToLower(spec)) {
+		if word == "none" {
+			continue
+		}
+
+		if param, ok := attributes[word]; ok {
+			params = append(params, param)
+			continue
+		}
+
+		if color, ok := colors[strings.TrimPrefix(word, "bright-")]; ok {
+			if strings.HasPrefix(word, "bright-") {
+				params = append(params, strconv.Itoa(90+color))
+			} else {
+				params = append(params, strconv.Itoa(30+color))
+			}
+
+			continue
+		}
+
+		if color, err := strconv.Atoi(word); err == nil && color >= 0 && color <= 255 {
+			params = append(params, fmt.Sprintf("38;5;%d", color))
+			continue
+		}
+
+		return "", fmt.Errorf("unknown style %q in %q", word, spec)
+	}
+
+	return Style(strings.Join(params, ";")), nil
+}
+
+// Apply styles the text with the ANSI escape sequences of the Style.
+func (s Style) Apply(text string) string {
+	if s == "" || text == "" {
+		return text
+	}
+
+	return "\x1b[" + string(s) + "m" + text + "\x1b[0m"
+}
+
+// Theme holds the Styles used for each part of a timeline.
+type Theme struct {
+	Nick       Style
+	Timestamp  Style
+	Mention    Style
+	OwnMention Style
+	Hashtag    Style
+	URL        Style
+}
+
+// DefaultTheme is the Theme used for any colors that are not configured.
+var DefaultTheme = Theme{
+	Nick:       "1",
+	Timestamp:  "2",
+	Mention:    "36",
+	OwnMention: "1;33",
+	Hashtag:    "35",
+	URL:        "4;34",
+}
+
+// NewTheme creates a Theme from the [colors] section of the config, any
+// colors that are not set use the DefaultTheme. Returns an error if any of the
+// colors is not a valid style.
+func NewTheme(colors config.Colors) (Theme, error) {
+	theme := DefaultTheme
+
+	styles := []struct {
+		spec  string
+		style *Style
+	}{
+		{colors.Nick, &theme.Nick},
+		{colors.Timestamp, &theme.Timestamp},
+		{colors.Mention, &theme.Mention},
+		{colors.OwnMention, &theme.OwnMention},
+		{colors.Hashtag, &theme.Hashtag},
+		{colors.URL, &theme.URL},
+	}
+
+	for _, s := range styles {
+		if s.spec == "" {
+			continue
+		}
+
+		style, err := ParseStyle(s.spec)
+		if err != nil {
+			return Theme{}, err
+		}
+
+		*s.style = style
+	}
+
+	return theme, nil
+}
+
+// highlights matches the parts of a post that are highlighted by a Theme, in
+// order these are mentions, urls, and hashtags.
+var highlights = regexp.MustCompile(`@<(?:[^ >]+ )?([^ >]+)>|https?://[^\s<>]+|\B#[\p{L}\p{N}_]+`)
+
+// Highlight styles the mentions, hashtags, and urls in the text of a post.
+// Mentions of the twturl, i.e. mentions of you, use the OwnMention style.
+func (theme Theme) Highlight(text, twturl string) string {
+	return highlights.ReplaceAllStringFunc(text, func(match string) string {
+		switch {
+		case strings.HasPrefix(match, "@<"):
+			url := highlights.FindStringSubmatch(match)[1]
+
+			if twturl != "" && url == twturl {
+				return theme.OwnMention.Apply(match)
+			}
+
+			return theme.Mention.Apply(match)
+		case strings.HasPrefix(match, "#"):
+			return theme.Hashtag.Apply(match)
+		default:
+			return theme.URL.Apply(match)
+		}
+	})
+}
+
+// UseColor reports if output to out should be styled. Colors are disabled in
+// porcelain mode, when the NO_COLOR environment variable is set, or when out
+// is not a terminal.
+//
+// See https://no-color.org for more information on NO_COLOR.
+func UseColor(out io.Writer, porcelain bool) bool {
+	if porcelain {
+		return false
+	}
+
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+
+	file, ok := out.(*os.File)
+	if !ok {
+		return false
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
+}
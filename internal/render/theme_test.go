@@ -0,0 +1,185 @@
+package render
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"duriny.envs.sh/twtr/twtxt/config"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseStyle(t *testing.T) {
+	tests := []struct {
+		spec  string
+		style Style
+		err   bool
+	}{
+		{spec: "", style: ""},
+		{spec: "none", style: ""},
+		{spec: "bold", style: "1"},
+		{spec: "bold blue", style: "1;34"},
+		{spec: "Underline Bright-Red", style: "4;91"},
+		{spec: "dim italic reverse white", style: "2;3;7;37"},
+		{spec: "208", style: "38;5;208"},
+		{spec: "bold 256", err: true},
+		{spec: "sparkly", err: true},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.spec, func(t *testing.T) {
+			have, err := ParseStyle(test.spec)
+
+			if (err != nil) != test.err {
+				t.Fatalf("err = %v, want error %t", err, test.err)
+			}
+
+			if want := test.style; have != want {
+				t.Errorf("have %q, want %q", have, want)
+			}
+		})
+	}
+}
+
+func TestStyleApply(t *testing.T) {
+	if have, want := Style("1;34").Apply("buckket"), "\x1b[1;34mbuckket\x1b[0m"; have != want {
+		t.Errorf("have %q, want %q", have, want)
+	}
+
+	if have, want := Style("").Apply("buckket"), "buckket"; have != want {
+		t.Errorf("have %q, want %q", have, want)
+	}
+}
+
+func TestNewTheme(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		theme, err := NewTheme(config.Colors{})
+		if err != nil {
+			t.Fatalf("unexpected error: %q", err)
+		}
+
+		if diff := cmp.Diff(theme, DefaultTheme); diff != "" {
+			t.Errorf("diff:\n%s", diff)
+		}
+	})
+
+	t.Run("Configured", func(t *testing.T) {
+		theme, err := NewTheme(config.Colors{
+			Nick:    "green",
+			Hashtag: "none",
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %q", err)
+		}
+
+		want := DefaultTheme
+		want.Nick = "32"
+		want.Hashtag = ""
+
+		if diff := cmp.Diff(theme, want); diff != "" {
+			t.Errorf("diff:\n%s", diff)
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		if _, err := NewTheme(config.Colors{URL: "sparkly"}); err == nil {
+			t.Error("want error but got nil")
+		}
+	})
+}
+
+func TestHighlight(t *testing.T) {
+	theme := Theme{
+		Mention:    "M",
+		OwnMention: "O",
+		Hashtag:    "H",
+		URL:        "U",
+	}
+
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{
+			name: "PlainText",
+			text: "Fiat lux!",
+			want: "Fiat lux!",
+		},
+		{
+			name: "Mention",
+			text: "@<example http://example.org/twtxt.txt> welcome to twtxt!",
+			want: "\x1b[Mm@<example http://example.org/twtxt.txt>\x1b[0m welcome to twtxt!",
+		},
+		{
+			name: "OwnMention",
+			text: "hi @<buckket https://buckket.example.org/twtxt.txt>",
+			want: "hi \x1b[Om@<buckket https://buckket.example.org/twtxt.txt>\x1b[0m",
+		},
+		{
+			name: "OwnMentionWithoutNick",
+			text: "hi @<https://buckket.example.org/twtxt.txt>",
+			want: "hi \x1b[Om@<https://buckket.example.org/twtxt.txt>\x1b[0m",
+		},
+		{
+			name: "Hashtag",
+			text: "#twtxt is great, C# is not a hashtag",
+			want: "\x1b[Hm#twtxt\x1b[0m is great, C# is not a hashtag",
+		},
+		{
+			name: "URL",
+			text: "see https://example.org/page#anchor for more",
+			want: "see \x1b[Umhttps://example.org/page#anchor\x1b[0m for more",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			have := theme.Highlight(test.text, "https://buckket.example.org/twtxt.txt")
+
+			if diff := cmp.Diff(have, test.want); diff != "" {
+				t.Errorf("diff:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUseColor(t *testing.T) {
+	file, err := os.Create(filepath.Join(t.TempDir(), "output"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer file.Close()
+
+	t.Run("Buffer", func(t *testing.T) {
+		if UseColor(&bytes.Buffer{}, false) {
+			t.Error("want no color for a buffer")
+		}
+	})
+
+	t.Run("RegularFile", func(t *testing.T) {
+		if UseColor(file, false) {
+			t.Error("want no color for a regular file")
+		}
+	})
+
+	t.Run("Porcelain", func(t *testing.T) {
+		if UseColor(os.Stdout, true) {
+			t.Error("want no color in porcelain mode")
+		}
+	})
+
+	t.Run("NoColor", func(t *testing.T) {
+		t.Setenv("NO_COLOR", "1")
+
+		if UseColor(os.Stdout, false) {
+			t.Error("want no color when NO_COLOR is set")
+		}
+	})
+}
@@ -0,0 +1,25 @@
+package timeline
+
+import "duriny.envs.sh/twtr/twtxt/export"
+
+// Export converts the Timeline into an export.Feed with the given title, the
+// url is the link of the Feed, e.g. your own twturl. Each Entry keeps the nick
+// and url of the feed that it was posted in, as its author.
+func (tl Timeline) Export(title, url string) *export.Feed {
+	feed := &export.Feed{
+		Title: title,
+		URL:   url,
+	}
+
+	for _, entry := range tl {
+		feed.Add(export.Item{
+			Hash:   entry.Hash(),
+			Author: entry.Nick,
+			URL:    entry.URL,
+			Time:   entry.Tweet.Time(),
+			Text:   entry.Tweet.Text(),
+		})
+	}
+
+	return feed
+}
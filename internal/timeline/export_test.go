@@ -0,0 +1,38 @@
+package timeline
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExport(t *testing.T) {
+	timeline := append(
+		New("alice", "https://example.org/alice.txt", parse(t,
+			"2016-02-03T23:05:00+01:00\twelcome to twtxt!",
+		).Tweets),
+		New("bob", "https://example.org/bob.txt", parse(t,
+			"2016-02-04T13:30:00+01:00\tlunch?",
+		).Tweets)...,
+	)
+
+	feed := timeline.Export("timeline", "https://example.org/alice.txt")
+
+	if have, want := feed.Updated, timeline[1].Tweet.Time(); !have.Equal(want) {
+		t.Errorf("have updated %s, want %s", have, want)
+	}
+
+	have := make([][2]string, 0)
+	for _, item := range feed.Items {
+		have = append(have, [2]string{item.Author, item.ID()})
+	}
+
+	want := [][2]string{
+		{"alice", "https://example.org/alice.txt#" + timeline[0].Hash()},
+		{"bob", "https://example.org/bob.txt#" + timeline[1].Hash()},
+	}
+
+	if !cmp.Equal(have, want) {
+		t.Errorf("diff:\n%s", cmp.Diff(have, want))
+	}
+}
@@ -0,0 +1,109 @@
+package timeline
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"duriny.envs.sh/twtr/twtxt/config"
+)
+
+// Filters are the rules from the [filters] section of the config, ready to be
+// matched against the Entries of a Timeline.
+type Filters struct {
+	nicks   map[string]bool
+	urls    map[string]bool
+	hashes  map[string]bool
+	threads map[string]bool
+	regexes []*regexp.Regexp
+}
+
+// NewFilters prepares the rules from the [filters] section of the config, any
+// rules that have expired by now are left out. Returns an error if a rule has
+// an unknown type, is missing a pattern, or has an invalid regex.
+func NewFilters(filters map[string]config.Filter, now time.Time) (*Filters, error) {
+	f := &Filters{
+		nicks:   make(map[string]bool),
+		urls:    make(map[string]bool),
+		hashes:  make(map[string]bool),
+		threads: make(map[string]bool),
+	}
+
+	for name, filter := range filters {
+		if filter.Expired(now) {
+			continue
+		}
+
+		if filter.Pattern == "" {
+			return nil, fmt.Errorf("filter %q: missing pattern", name)
+		}
+
+		switch filter.Type {
+		case "nick":
+			f.nicks[filter.Pattern] = true
+		case "url":
+			f.urls[filter.Pattern] = true
+		case "hash":
+			f.hashes[filter.Pattern] = true
+		case "thread":
+			f.threads[filter.Pattern] = true
+		case "regex":
+			re, err := regexp.Compile(filter.Pattern)
+			if err != nil {
+				return nil, fmt.Errorf("filter %q: %w", name, err)
+			}
+
+			f.regexes = append(f.regexes, re)
+		default:
+			return nil, fmt.Errorf("filter %q: unknown type %q", name, filter.Type)
+		}
+	}
+
+	return f, nil
+}
+
+// Match reports if the Entry matches any of the Filters.
+func (f *Filters) Match(entry *Entry) bool {
+	if f.nicks[entry.Nick] || f.urls[entry.URL] {
+		return true
+	}
+
+	// only hash the tweet if there are hashes to compare
+	if len(f.hashes) > 0 || len(f.threads) > 0 {
+		hash := entry.Hash()
+
+		if f.hashes[hash] || f.threads[hash] || f.threads[entry.Tweet.Subject()] {
+			return true
+		}
+	}
+
+	for _, re := range f.regexes {
+		if re.MatchString(entry.Tweet.Text()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Filter returns the Entries of the Timeline that don't match the Filters. If
+// showMuted is true, then the matching Entries are kept, but marked as Muted.
+func (tl Timeline) Filter(f *Filters, showMuted bool) Timeline {
+	filtered := make(Timeline, 0, len(tl))
+
+	for _, entry := range tl {
+		if !f.Match(entry) {
+			filtered = append(filtered, entry)
+			continue
+		}
+
+		if showMuted {
+			muted := *entry
+			muted.Muted = true
+
+			filtered = append(filtered, &muted)
+		}
+	}
+
+	return filtered
+}
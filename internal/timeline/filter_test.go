@@ -0,0 +1,196 @@
+package timeline
+
+import (
+	"testing"
+	"time"
+
+	"duriny.envs.sh/twtr/twtxt/config"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFilters(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	timeline := append(
+		New("alice", "https://example.org/alice.txt", parse(t,
+			"2016-02-03T23:05:00+01:00\t@<bob https://example.org/bob.txt> welcome to twtxt!",
+			"2016-02-04T13:30:00+01:00\tWe deployed the new release",
+		).Tweets),
+		append(
+			New("bob", "https://example.org/bob.txt", parse(t,
+				"2015-12-12T12:00:00+01:00\tFiat lux!",
+				"2016-02-04T00:00:00+01:00\t(#hbdjgiq) Thanks alice!",
+			).Tweets),
+			New("newsbot", "https://example.org/news.txt", parse(t,
+				"2016-02-04T00:00:00+01:00\tBREAKING: bot posts news",
+			).Tweets)...,
+		)...,
+	)
+
+	tests := []struct {
+		name    string
+		filters map[string]config.Filter
+		want    []string
+		err     bool
+	}{
+		{
+			name: "NoFilters",
+			want: []string{
+				"@<bob https://example.org/bob.txt> welcome to twtxt!",
+				"We deployed the new release",
+				"Fiat lux!",
+				"(#hbdjgiq) Thanks alice!",
+				"BREAKING: bot posts news",
+			},
+		},
+		{
+			name: "Nick",
+			filters: map[string]config.Filter{
+				"bots": {Type: "nick", Pattern: "newsbot"},
+			},
+			want: []string{
+				"@<bob https://example.org/bob.txt> welcome to twtxt!",
+				"We deployed the new release",
+				"Fiat lux!",
+				"(#hbdjgiq) Thanks alice!",
+			},
+		},
+		{
+			name: "URL",
+			filters: map[string]config.Filter{
+				"bob": {Type: "url", Pattern: "https://example.org/bob.txt"},
+			},
+			want: []string{
+				"@<bob https://example.org/bob.txt> welcome to twtxt!",
+				"We deployed the new release",
+				"BREAKING: bot posts news",
+			},
+		},
+		{
+			name: "Hash",
+			filters: map[string]config.Filter{
+				"welcome": {Type: "hash", Pattern: New("alice", "https://example.org/alice.txt", parse(t,
+					"2016-02-03T23:05:00+01:00\t@<bob https://example.org/bob.txt> welcome to twtxt!",
+				).Tweets)[0].Hash()},
+			},
+			want: []string{
+				"We deployed the new release",
+				"Fiat lux!",
+				"(#hbdjgiq) Thanks alice!",
+				"BREAKING: bot posts news",
+			},
+		},
+		{
+			name: "Thread",
+			filters: map[string]config.Filter{
+				"welcome": {Type: "thread", Pattern: "hbdjgiq"},
+			},
+			want: []string{
+				"@<bob https://example.org/bob.txt> welcome to twtxt!",
+				"We deployed the new release",
+				"Fiat lux!",
+				"BREAKING: bot posts news",
+			},
+		},
+		{
+			name: "Regex",
+			filters: map[string]config.Filter{
+				"deploys": {Type: "regex", Pattern: "(?i)deploy(ed|ing)?"},
+				"news":    {Type: "regex", Pattern: "^BREAKING:"},
+			},
+			want: []string{
+				"@<bob https://example.org/bob.txt> welcome to twtxt!",
+				"Fiat lux!",
+				"(#hbdjgiq) Thanks alice!",
+			},
+		},
+		{
+			name: "Expired",
+			filters: map[string]config.Filter{
+				"bots": {Type: "nick", Pattern: "newsbot", Expires: now},
+				"bob":  {Type: "nick", Pattern: "bob", Expires: now.Add(time.Hour)},
+			},
+			want: []string{
+				"@<bob https://example.org/bob.txt> welcome to twtxt!",
+				"We deployed the new release",
+				"BREAKING: bot posts news",
+			},
+		},
+		{
+			name: "UnknownType",
+			filters: map[string]config.Filter{
+				"bots": {Type: "robot", Pattern: "newsbot"},
+			},
+			err: true,
+		},
+		{
+			name: "MissingPattern",
+			filters: map[string]config.Filter{
+				"bots": {Type: "nick"},
+			},
+			err: true,
+		},
+		{
+			name: "InvalidRegex",
+			filters: map[string]config.Filter{
+				"bots": {Type: "regex", Pattern: "(unclosed"},
+			},
+			err: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			filters, err := NewFilters(test.filters, now)
+
+			if (err != nil) != test.err {
+				t.Fatalf("err = %v, want error %t", err, test.err)
+			}
+
+			if err != nil {
+				return
+			}
+
+			t.Run("Hidden", func(t *testing.T) {
+				var have []string
+
+				for _, entry := range timeline.Filter(filters, false) {
+					have = append(have, entry.Tweet.Text())
+				}
+
+				if diff := cmp.Diff(have, test.want); diff != "" {
+					t.Errorf("diff:\n%s", diff)
+				}
+			})
+
+			t.Run("ShowMuted", func(t *testing.T) {
+				var have []string
+
+				shown := timeline.Filter(filters, true)
+
+				if have, want := len(shown), len(timeline); have != want {
+					t.Fatalf("have %d entries, want %d", have, want)
+				}
+
+				for _, entry := range shown {
+					if !entry.Muted {
+						have = append(have, entry.Tweet.Text())
+					}
+				}
+
+				if diff := cmp.Diff(have, test.want); diff != "" {
+					t.Errorf("diff:\n%s", diff)
+				}
+
+				// the original timeline is not changed
+				for _, entry := range timeline {
+					if entry.Muted {
+						t.Errorf("entry %q was muted in the original timeline", entry.Tweet.Text())
+					}
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package timeline
+
+// Mentioning returns the Entries of the Timeline that mention the feed at url,
+// typically the twturl of your own feed.
+func (tl Timeline) Mentioning(url string) Timeline {
+	mentions := make(Timeline, 0)
+
+	for _, entry := range tl {
+		for _, mention := range entry.Tweet.Mentions() {
+			if mention.Mentions(url) {
+				mentions = append(mentions, entry)
+				break
+			}
+		}
+	}
+
+	return mentions
+}
@@ -0,0 +1,56 @@
+package timeline
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMentioning(t *testing.T) {
+	timeline := append(
+		New("alice", "https://example.org/alice.txt", parse(t,
+			"2016-02-03T23:05:00+01:00\t@<bob https://example.org/bob.txt> welcome to twtxt!",
+			"2016-02-04T13:30:00+01:00\t@<carol https://example.org/carol.txt> @<https://example.org/bob.txt/> lunch?",
+			"2016-02-05T09:00:00+01:00\tbob.txt is a great feed",
+		).Tweets),
+		New("carol", "https://example.org/carol.txt", parse(t,
+			"2016-02-04T14:00:00+01:00\t@<alice https://example.org/alice.txt> yes please!",
+		).Tweets)...,
+	)
+
+	tests := []struct {
+		url  string
+		want []string
+	}{
+		{
+			url: "https://example.org/bob.txt",
+			want: []string{
+				"@<bob https://example.org/bob.txt> welcome to twtxt!",
+				"@<carol https://example.org/carol.txt> @<https://example.org/bob.txt/> lunch?",
+			},
+		},
+		{
+			url:  "https://example.org/alice.txt",
+			want: []string{"@<alice https://example.org/alice.txt> yes please!"},
+		},
+		{
+			url:  "https://example.org/dave.txt",
+			want: []string{},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.url, func(t *testing.T) {
+			have := make([]string, 0)
+			for _, entry := range timeline.Mentioning(test.url) {
+				have = append(have, entry.Tweet.Text())
+			}
+
+			if diff := cmp.Diff(test.want, have); diff != "" {
+				t.Errorf("unexpected mentions (-want +have):\n%s", diff)
+			}
+		})
+	}
+}
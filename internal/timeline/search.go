@@ -0,0 +1,90 @@
+package timeline
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// localFormats are the formats of a date, or a date and time, without a time
+// zone, these are in the local time zone.
+var localFormats = []string{
+	"2006-01-02",
+	"2006-01-02T15:04",
+	"2006-01-02T15:04:05",
+}
+
+// ParseTime parses a time given on the command line, either as a date or a date
+// and time in the local time zone, e.g. 2022-03-01 or 2022-03-01T09:30, or as
+// an RFC3339 timestamp.
+func ParseTime(value string) (time.Time, error) {
+	for _, format := range localFormats {
+		if t, err := time.ParseInLocation(format, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Parse(time.RFC3339, value)
+}
+
+// Query is a search for Entries in a Timeline, an Entry has to match every
+// part of the Query that is set.
+type Query struct {
+	// Text is searched for in the text of each Tweet, ignoring case. If Regex
+	// is true, then Text is a regular expression instead.
+	Text  string
+	Regex bool
+
+	// From is the nick of the feed that the Tweet was posted in.
+	From string
+
+	// Since is the earliest time that the Tweet was posted, and Until is the
+	// time that the Tweet was posted before.
+	Since time.Time
+	Until time.Time
+}
+
+// Search returns the Entries of the Timeline that match the Query. Returns an
+// error if the Query is a regex search with an invalid regular expression.
+func (tl Timeline) Search(q Query) (Timeline, error) {
+	var match func(string) bool
+
+	if q.Regex {
+		re, err := regexp.Compile(q.Text)
+		if err != nil {
+			return nil, err
+		}
+
+		match = re.MatchString
+	} else {
+		text := strings.ToLower(q.Text)
+
+		match = func(s string) bool {
+			return strings.Contains(strings.ToLower(s), text)
+		}
+	}
+
+	found := make(Timeline, 0)
+
+	for _, entry := range tl {
+		if q.From != "" && entry.Nick != q.From {
+			continue
+		}
+
+		if !q.Since.IsZero() && entry.Tweet.Time().Before(q.Since) {
+			continue
+		}
+
+		if !q.Until.IsZero() && !entry.Tweet.Time().Before(q.Until) {
+			continue
+		}
+
+		if !match(entry.Tweet.Text()) {
+			continue
+		}
+
+		found = append(found, entry)
+	}
+
+	return found, nil
+}
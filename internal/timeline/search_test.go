@@ -0,0 +1,147 @@
+package timeline
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseTime(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Time
+		err   bool
+	}{
+		{
+			value: "2022-03-01",
+			want:  time.Date(2022, 3, 1, 0, 0, 0, 0, time.Local),
+		},
+		{
+			value: "2022-03-01T09:30",
+			want:  time.Date(2022, 3, 1, 9, 30, 0, 0, time.Local),
+		},
+		{
+			value: "2022-03-01T09:30:15",
+			want:  time.Date(2022, 3, 1, 9, 30, 15, 0, time.Local),
+		},
+		{
+			value: "2022-03-01T09:30:00+13:00",
+			want:  time.Date(2022, 2, 28, 20, 30, 0, 0, time.UTC),
+		},
+		{
+			value: "March 2022",
+			err:   true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.value, func(t *testing.T) {
+			have, err := ParseTime(test.value)
+
+			if (err != nil) != test.err {
+				t.Fatalf("err = %v, want error %t", err, test.err)
+			}
+
+			if !have.Equal(test.want) {
+				t.Errorf("have %s, want %s", have, test.want)
+			}
+		})
+	}
+}
+
+func TestSearch(t *testing.T) {
+	timeline := append(
+		New("alice", "https://example.org/alice.txt", parse(t,
+			"2022-02-28T09:00:00Z\tDeployed v1.2.0 to staging",
+			"2022-03-14T16:30:00Z\tDeployed v1.3.0 to production, rollback notes in the wiki",
+			"2022-04-01T12:00:00Z\tNo deploys today, it's a holiday",
+		).Tweets),
+		New("bob", "https://example.org/bob.txt", parse(t,
+			"2022-03-02T10:00:00Z\tdeployed the new database",
+			"2022-03-20T10:00:00Z\tFiat lux!",
+		).Tweets)...,
+	)
+
+	tests := []struct {
+		name  string
+		query Query
+		want  []string
+		err   bool
+	}{
+		{
+			name:  "Text",
+			query: Query{Text: "deployed"},
+			want: []string{
+				"Deployed v1.2.0 to staging",
+				"Deployed v1.3.0 to production, rollback notes in the wiki",
+				"deployed the new database",
+			},
+		},
+		{
+			name:  "TextIsNotRegex",
+			query: Query{Text: "v1.2.0"},
+			want: []string{
+				"Deployed v1.2.0 to staging",
+			},
+		},
+		{
+			name:  "Regex",
+			query: Query{Text: `^Deployed v1\.\d\.0 to prod`, Regex: true},
+			want: []string{
+				"Deployed v1.3.0 to production, rollback notes in the wiki",
+			},
+		},
+		{
+			name:  "From",
+			query: Query{Text: "deployed", From: "bob"},
+			want: []string{
+				"deployed the new database",
+			},
+		},
+		{
+			name: "SinceAndUntil",
+			query: Query{
+				Text:  "deploy",
+				Since: time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC),
+				Until: time.Date(2022, 4, 1, 12, 0, 0, 0, time.UTC),
+			},
+			want: []string{
+				"Deployed v1.3.0 to production, rollback notes in the wiki",
+				"deployed the new database",
+			},
+		},
+		{
+			name:  "Nothing",
+			query: Query{Text: "kubernetes"},
+		},
+		{
+			name:  "InvalidRegex",
+			query: Query{Text: "(unclosed", Regex: true},
+			err:   true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			found, err := timeline.Search(test.query)
+
+			if (err != nil) != test.err {
+				t.Fatalf("err = %v, want error %t", err, test.err)
+			}
+
+			var have []string
+			for _, entry := range found {
+				have = append(have, entry.Tweet.Text())
+			}
+
+			if diff := cmp.Diff(have, test.want); diff != "" {
+				t.Errorf("diff:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package timeline
+
+import "duriny.envs.sh/twtr/twtxt"
+
+// Entry is a Tweet in a Timeline, along with the nick and url of the feed that
+// the Tweet was posted in.
+type Entry struct {
+	Nick  string
+	URL   string
+	Tweet *twtxt.Tweet
+	Muted bool
+}
+
+// Hash returns the twt hash of the Tweet in the Entry.
+func (entry *Entry) Hash() string {
+	return entry.Tweet.Hash(entry.URL)
+}
+
+// Timeline is a collection of Entries, these are usually from many different
+// feeds. A Timeline can be sorted by the timestamp of the Tweets.
+type Timeline []*Entry
+
+// New creates a Timeline from the Tweets of a single feed, a Timeline of many
+// feeds is created by appending the Timelines of each feed together.
+func New(nick, url string, tweets twtxt.Tweets) Timeline {
+	timeline := make(Timeline, len(tweets))
+
+	for i, tweet := range tweets {
+		timeline[i] = &Entry{
+			Nick:  nick,
+			URL:   url,
+			Tweet: tweet,
+		}
+	}
+
+	return timeline
+}
+
+// Len reports the number of Entries.
+func (tl Timeline) Len() int { return len(tl) }
+
+// Less reports if the Tweet at i was posted before the Tweet at j.
+func (tl Timeline) Less(i, j int) bool { return tl[i].Tweet.Before(tl[j].Tweet) }
+
+// Swap exchanges the Entry at i with the Entry at j.
+func (tl Timeline) Swap(i, j int) { tl[i], tl[j] = tl[j], tl[i] }
@@ -0,0 +1,59 @@
+package timeline
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"duriny.envs.sh/twtr/twtxt"
+)
+
+// parse is a helper to create a twtxt file from its lines.
+func parse(t *testing.T, lines ...string) *twtxt.File {
+	t.Helper()
+
+	file, err := twtxt.Parse(strings.NewReader(strings.Join(lines, "\n")))
+	if err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	return file
+}
+
+func TestTimeline(t *testing.T) {
+	alice := New("alice", "https://example.org/alice.txt", parse(t,
+		"2016-02-03T23:05:00+01:00\t@<bob https://example.org/bob.txt> welcome to twtxt!",
+		"2016-02-04T13:30:00+01:00\tYou can really go crazy here! ┐(ﾟ∀ﾟ)┌",
+	).Tweets)
+
+	bob := New("bob", "https://example.org/bob.txt", parse(t,
+		"2015-12-12T12:00:00+01:00\tFiat lux!",
+		"2016-02-04T00:00:00+01:00\tThanks alice!",
+	).Tweets)
+
+	for _, entry := range alice {
+		if entry.Nick != "alice" || entry.URL != "https://example.org/alice.txt" {
+			t.Errorf("have %s %s, want alice https://example.org/alice.txt", entry.Nick, entry.URL)
+		}
+	}
+
+	timeline := append(alice, bob...)
+	sort.Sort(timeline)
+
+	want := []string{
+		"Fiat lux!",
+		"@<bob https://example.org/bob.txt> welcome to twtxt!",
+		"Thanks alice!",
+		"You can really go crazy here! ┐(ﾟ∀ﾟ)┌",
+	}
+
+	if have, want := timeline.Len(), len(want); have != want {
+		t.Fatalf("have %d entries, want %d", have, want)
+	}
+
+	for i, entry := range timeline {
+		if have, want := entry.Tweet.Text(), want[i]; have != want {
+			t.Errorf("entry %d: have %q, want %q", i, have, want)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package timeline
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"time"
+
+	"duriny.envs.sh/twtr/internal/atomicfile"
+)
+
+// Marker is the "last seen" marker of a feed. Rather than the timestamp of the
+// newest Tweet, which breaks when a feed's timestamps go backwards or old posts
+// are inserted, a Marker records the twt hashes of the Tweets that were seen.
+type Marker struct {
+	Read   time.Time       `json:"read"`
+	Hashes map[string]bool `json:"hashes"`
+}
+
+// Markers are the "last seen" markers of every feed, keyed by the feed's url,
+// they are saved to a file between runs.
+type Markers struct {
+	path  string
+	feeds map[string]*Marker
+}
+
+// OpenMarkers reads the Markers saved in the file at path, if there is no such
+// file then no feeds have been read yet.
+func OpenMarkers(path string) (*Markers, error) {
+	m := &Markers{
+		path:  path,
+		feeds: make(map[string]*Marker),
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return m, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &m.feeds); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// Save writes the Markers to their file, see atomicfile.WriteFile.
+func (m *Markers) Save() error {
+	data, err := json.Marshal(m.feeds)
+	if err != nil {
+		return err
+	}
+
+	return atomicfile.WriteFile(m.path, data, 0o600)
+}
+
+// Marker returns the Marker of the feed at url, or nil if it was never read.
+func (m *Markers) Marker(url string) *Marker {
+	return m.feeds[url]
+}
+
+// Unread reports whether the Entry has not been seen, Entries of feeds that
+// were never read are all unread.
+func (m *Markers) Unread(entry *Entry) bool {
+	marker := m.feeds[entry.URL]
+
+	return marker == nil || !marker.Hashes[entry.Hash()]
+}
+
+// Mark records the Entries of a Timeline as seen, typically after they have
+// been shown, updating the Marker of each of their feeds.
+func (m *Markers) Mark(tl Timeline, now time.Time) {
+	for _, entry := range tl {
+		marker := m.feeds[entry.URL]
+		if marker == nil {
+			marker = &Marker{Hashes: make(map[string]bool)}
+			m.feeds[entry.URL] = marker
+		}
+
+		marker.Read = now
+		marker.Hashes[entry.Hash()] = true
+	}
+}
+
+// Prune forgets the hashes of Tweets that are no longer in their feeds, so that
+// the Markers don't grow forever. The Timeline has to contain every Tweet of
+// its feeds, the Markers of feeds that are not in the Timeline are kept.
+func (m *Markers) Prune(tl Timeline) {
+	hashes := make(map[string]map[string]bool)
+
+	for _, entry := range tl {
+		if hashes[entry.URL] == nil {
+			hashes[entry.URL] = make(map[string]bool)
+		}
+
+		hashes[entry.URL][entry.Hash()] = true
+	}
+
+	for url, feed := range hashes {
+		marker := m.feeds[url]
+		if marker == nil {
+			continue
+		}
+
+		for hash := range marker.Hashes {
+			if !feed[hash] {
+				delete(marker.Hashes, hash)
+			}
+		}
+	}
+}
+
+// Unread returns the Entries of the Timeline that have not been seen.
+func (tl Timeline) Unread(m *Markers) Timeline {
+	unread := make(Timeline, 0, len(tl))
+
+	for _, entry := range tl {
+		if m.Unread(entry) {
+			unread = append(unread, entry)
+		}
+	}
+
+	return unread
+}
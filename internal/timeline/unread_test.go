@@ -0,0 +1,97 @@
+package timeline
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMarkers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "twtr", "unread.json")
+	now := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	// texts is a helper to list the posts of a Timeline.
+	texts := func(tl Timeline) []string {
+		have := make([]string, 0, len(tl))
+		for _, entry := range tl {
+			have = append(have, entry.Tweet.Text())
+		}
+
+		return have
+	}
+
+	markers, err := OpenMarkers(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	alice := New("alice", "https://example.org/alice.txt", parse(t,
+		"2022-02-27T09:00:00Z\tFirst!",
+		"2022-02-28T09:00:00Z\tSecond!",
+	).Tweets)
+
+	bob := New("bob", "https://example.org/bob.txt", parse(t,
+		"2022-02-28T10:00:00Z\tFiat lux!",
+	).Tweets)
+
+	// feeds that were never read are unread
+	if diff := cmp.Diff([]string{"First!", "Second!", "Fiat lux!"}, texts(append(alice, bob...).Unread(markers))); diff != "" {
+		t.Errorf("unexpected unread (-want +have):\n%s", diff)
+	}
+
+	markers.Mark(alice, now)
+
+	if err := markers.Save(); err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	markers, err = OpenMarkers(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	if marker := markers.Marker("https://example.org/alice.txt"); marker == nil || !marker.Read.Equal(now) {
+		t.Errorf("have marker %v, want one read at %s", marker, now)
+	}
+
+	if marker := markers.Marker("https://example.org/bob.txt"); marker != nil {
+		t.Errorf("have marker %v for an unread feed, want nil", marker)
+	}
+
+	// new posts are unread, even those with older timestamps than the posts
+	// that were seen, or timestamps that go backwards
+	alice = New("alice", "https://example.org/alice.txt", parse(t,
+		"2022-02-27T09:00:00Z\tFirst!",
+		"2022-02-28T09:00:00Z\tSecond!",
+		"2022-02-26T09:00:00Z\tBackdated!",
+		"2022-03-01T09:00:00Z\tThird!",
+		"2022-02-28T23:00:00Z\tClock skew!",
+	).Tweets)
+
+	want := []string{"Backdated!", "Third!", "Clock skew!", "Fiat lux!"}
+	if diff := cmp.Diff(want, texts(append(alice, bob...).Unread(markers))); diff != "" {
+		t.Errorf("unexpected unread (-want +have):\n%s", diff)
+	}
+
+	// only the posts that were shown are marked as read
+	markers.Mark(alice[2:4], now)
+
+	want = []string{"Clock skew!", "Fiat lux!"}
+	if diff := cmp.Diff(want, texts(append(alice, bob...).Unread(markers))); diff != "" {
+		t.Errorf("unexpected unread (-want +have):\n%s", diff)
+	}
+
+	// posts that were deleted from the feed are forgotten
+	markers.Prune(alice[1:])
+
+	if have := len(markers.Marker("https://example.org/alice.txt").Hashes); have != 3 {
+		t.Errorf("have %d hashes after pruning, want 3", have)
+	}
+
+	want = []string{"First!", "Clock skew!", "Fiat lux!"}
+	if diff := cmp.Diff(want, texts(append(alice, bob...).Unread(markers))); diff != "" {
+		t.Errorf("unexpected unread (-want +have):\n%s", diff)
+	}
+}
@@ -0,0 +1,124 @@
+package twtfile
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+
+	"duriny.envs.sh/twtr/twtxt"
+)
+
+var (
+	// ErrNotFound is returned when no Tweet in the twtfile matches a reference.
+	ErrNotFound = errors.New("tweet not found")
+
+	// ErrEmpty is returned when a Tweet is edited to have no text, delete the
+	// Tweet instead.
+	ErrEmpty = errors.New("empty tweet")
+)
+
+// Find returns the index of the line of the Tweet that ref refers to, ref is
+// either the twt hash of the Tweet, as posted at url, or its INDEX counting back
+// from your newest Tweet, where 1 is the newest.
+func Find(lines []Line, url, ref string) (int, error) {
+	ref = strings.TrimPrefix(strings.TrimSpace(ref), "#")
+
+	for i, line := range lines {
+		if line.Tweet != nil && line.Tweet.Hash(url) == ref {
+			return i, nil
+		}
+	}
+
+	index, err := strconv.Atoi(ref)
+	if err != nil || index < 1 {
+		return 0, ErrNotFound
+	}
+
+	// the line of each Tweet, newest first
+	tweets := make([]int, 0, len(lines))
+	for i, line := range lines {
+		if line.Tweet != nil {
+			tweets = append(tweets, i)
+		}
+	}
+
+	sort.SliceStable(tweets, func(i, j int) bool {
+		return lines[tweets[i]].Tweet.After(lines[tweets[j]].Tweet)
+	})
+
+	if index > len(tweets) {
+		return 0, ErrNotFound
+	}
+
+	return tweets[index-1], nil
+}
+
+// Get returns the Tweet in the twtfile at path that ref refers to, see Find.
+func Get(path, url, ref string) (*twtxt.Tweet, error) {
+	lines, err := Read(path)
+	if err != nil {
+		return nil, err
+	}
+
+	i, err := Find(lines, url, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	return lines[i].Tweet, nil
+}
+
+// Delete removes the Tweet that ref refers to, see Find, from the twtfile at
+// path, and returns the deleted Tweet.
+func Delete(path, url, ref string) (*twtxt.Tweet, error) {
+	lines, err := Read(path)
+	if err != nil {
+		return nil, err
+	}
+
+	i, err := Find(lines, url, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	tweet := lines[i].Tweet
+
+	if err := Write(path, append(lines[:i:i], lines[i+1:]...)); err != nil {
+		return nil, err
+	}
+
+	return tweet, nil
+}
+
+// Edit replaces the text of the Tweet that ref refers to, see Find, in the
+// twtfile at path, and returns the edited Tweet. The Tweet keeps its original
+// timestamp, but as its text changes, so does its twt hash.
+//
+// To edit a Tweet that was looked up by its INDEX, e.g. with Get, pass its twt
+// hash as the ref, so that the same Tweet is edited even if the twtfile was
+// changed in the meantime.
+func Edit(path, url, ref, text string) (*twtxt.Tweet, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, ErrEmpty
+	}
+
+	lines, err := Read(path)
+	if err != nil {
+		return nil, err
+	}
+
+	i, err := Find(lines, url, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	tweet := twtxt.NewTweetAt(strings.TrimSpace(text), lines[i].Tweet.Time())
+	lines[i] = Line{Text: tweet.String(), Tweet: tweet}
+
+	if err := Write(path, lines); err != nil {
+		return nil, err
+	}
+
+	return tweet, nil
+}
@@ -0,0 +1,139 @@
+package twtfile
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"duriny.envs.sh/twtr/twtxt"
+)
+
+const url = "https://example.org/twtxt.txt"
+
+// feed is the twtfile for the tests, the newest Tweet isn't the last line.
+var feed = []string{
+	"# nick = alice",
+	"2016-02-03T23:05:00+01:00\twelcome to twtxt!",
+	"2016-02-05T09:00:00+01:00\tlunch?",
+	"2016-02-04T13:30:00+01:00\tYou can really go crazy here! ┐(ﾟ∀ﾟ)┌",
+}
+
+// hash is a helper to get the twt hash of a line of the feed.
+func hash(t *testing.T, line string) string {
+	t.Helper()
+
+	file, err := twtxt.Parse(strings.NewReader(line))
+	if err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	return file.Tweets[0].Hash(url)
+}
+
+// write is a helper to write the feed to a new twtfile.
+func write(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "twtxt.txt")
+	if err := os.WriteFile(path, []byte(strings.Join(feed, "\n")+"\n"), 0o644); err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	return path
+}
+
+func TestFind(t *testing.T) {
+	lines := make([]Line, 0)
+	for _, text := range feed {
+		line := Line{Text: text}
+
+		if !strings.HasPrefix(text, "#") {
+			file, _ := twtxt.Parse(strings.NewReader(text))
+			line.Tweet = file.Tweets[0]
+		}
+
+		lines = append(lines, line)
+	}
+
+	tests := []struct {
+		ref  string
+		want int
+		err  error
+	}{
+		{ref: hash(t, feed[1]), want: 1},
+		{ref: "#" + hash(t, feed[3]), want: 3},
+		{ref: "1", want: 2},
+		{ref: "2", want: 3},
+		{ref: "3", want: 1},
+		{ref: "4", err: ErrNotFound},
+		{ref: "0", err: ErrNotFound},
+		{ref: "abcdefg", err: ErrNotFound},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.ref, func(t *testing.T) {
+			have, err := Find(lines, url, test.ref)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("have error %v, want %v", err, test.err)
+			}
+
+			if err == nil && have != test.want {
+				t.Errorf("have line %d, want %d", have, test.want)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	path := write(t)
+
+	tweet, err := Delete(path, url, "1")
+	if err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	if have, want := tweet.Text(), "lunch?"; have != want {
+		t.Errorf("have deleted %q, want %q", have, want)
+	}
+
+	data, _ := os.ReadFile(path)
+	want := strings.Join([]string{feed[0], feed[1], feed[3]}, "\n") + "\n"
+
+	if have := string(data); have != want {
+		t.Errorf("have %q, want %q", have, want)
+	}
+
+	if _, err := Delete(path, url, hash(t, feed[2])); !errors.Is(err, ErrNotFound) {
+		t.Errorf("have error %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestEdit(t *testing.T) {
+	path := write(t)
+
+	tweet, err := Edit(path, url, hash(t, feed[1]), "welcome to twtxt!\nthe decentralised microblog\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	edited := "2016-02-03T23:05:00+01:00\twelcome to twtxt!\\nthe decentralised microblog"
+
+	if have, want := tweet.String(), edited; have != want {
+		t.Errorf("have %q, want %q", have, want)
+	}
+
+	data, _ := os.ReadFile(path)
+	want := strings.Join([]string{feed[0], edited, feed[2], feed[3]}, "\n") + "\n"
+
+	if have := string(data); have != want {
+		t.Errorf("have %q, want %q", have, want)
+	}
+
+	if _, err := Edit(path, url, "1", " \n"); !errors.Is(err, ErrEmpty) {
+		t.Errorf("have error %v, want %v", err, ErrEmpty)
+	}
+}
@@ -0,0 +1,77 @@
+package twtfile
+
+import (
+	"errors"
+	"io/fs"
+	"sort"
+	"time"
+
+	"duriny.envs.sh/twtr/twtxt"
+)
+
+// Merge merges the Tweets into the twtfile at path, and returns the number of
+// Tweets that were added. Tweets that are already in the twtfile, with the same
+// timestamp and text, are skipped, e.g. so an archive can be imported again.
+//
+// The lines of the file are kept as they are, each new Tweet is inserted before
+// the first Tweet of the file that was posted after it, so a file that is
+// sorted oldest first, as twtxt files are written, stays sorted. The file is
+// replaced atomically, and created if it doesn't exist yet.
+func Merge(path string, tweets twtxt.Tweets) (int, error) {
+	lines, err := Read(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return 0, err
+	}
+
+	seen := make(map[string]bool)
+
+	for _, line := range lines {
+		if line.Tweet != nil {
+			seen[key(line.Tweet)] = true
+		}
+	}
+
+	// only add each new Tweet once, oldest first
+	added := make(twtxt.Tweets, 0, len(tweets))
+
+	for _, tweet := range tweets {
+		if k := key(tweet); !seen[k] {
+			seen[k] = true
+			added = append(added, tweet)
+		}
+	}
+
+	if len(added) == 0 {
+		return 0, nil
+	}
+
+	sort.Stable(added)
+
+	count := len(added)
+	merged := make([]Line, 0, len(lines)+len(added))
+
+	for _, line := range lines {
+		for len(added) > 0 && line.Tweet != nil && added[0].Before(line.Tweet) {
+			merged = append(merged, Line{Text: added[0].String(), Tweet: added[0]})
+			added = added[1:]
+		}
+
+		merged = append(merged, line)
+	}
+
+	for _, tweet := range added {
+		merged = append(merged, Line{Text: tweet.String(), Tweet: tweet})
+	}
+
+	if err := Write(path, merged); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// key is a helper to Merge(), it identifies a Tweet by its timestamp and text,
+// as the twt hash depends on the url of the feed.
+func key(tweet *twtxt.Tweet) string {
+	return tweet.Time().UTC().Format(time.RFC3339) + "\t" + tweet.Text()
+}
@@ -0,0 +1,109 @@
+package twtfile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"duriny.envs.sh/twtr/twtxt"
+)
+
+func TestMerge(t *testing.T) {
+	at := func(day int, text string) *twtxt.Tweet {
+		return twtxt.NewTweetAt(text, time.Date(2022, 2, day, 9, 0, 0, 0, time.UTC))
+	}
+
+	tests := []struct {
+		name   string
+		file   string
+		tweets twtxt.Tweets
+		added  int
+		want   string
+	}{
+		{
+			name:   "NewFile",
+			tweets: twtxt.Tweets{at(2, "second"), at(1, "first")},
+			added:  2,
+			want:   "2022-02-01T09:00:00Z\tfirst\n2022-02-02T09:00:00Z\tsecond\n",
+		},
+		{
+			name: "Insert",
+			file: "# nick = alice\n" +
+				"2022-02-02T10:00:00+01:00\tsecond\n" +
+				"# comments are kept\n" +
+				"2022-02-04T09:00:00Z\tfourth\n",
+			tweets: twtxt.Tweets{at(5, "fifth"), at(3, "third"), at(1, "first")},
+			added:  3,
+			want: "# nick = alice\n" +
+				"2022-02-01T09:00:00Z\tfirst\n" +
+				"2022-02-02T10:00:00+01:00\tsecond\n" +
+				"# comments are kept\n" +
+				"2022-02-03T09:00:00Z\tthird\n" +
+				"2022-02-04T09:00:00Z\tfourth\n" +
+				"2022-02-05T09:00:00Z\tfifth\n",
+		},
+		{
+			name:   "Duplicates",
+			file:   "2022-02-01T10:00:00+01:00\tfirst\\nline\n",
+			tweets: twtxt.Tweets{at(1, "first\nline"), at(2, "second"), at(2, "second")},
+			added:  1,
+			want:   "2022-02-01T10:00:00+01:00\tfirst\\nline\n2022-02-02T09:00:00Z\tsecond\n",
+		},
+		{
+			name:   "Nothing",
+			file:   "2022-02-01T09:00:00Z\tfirst\n",
+			tweets: twtxt.Tweets{at(1, "first")},
+			added:  0,
+			want:   "2022-02-01T09:00:00Z\tfirst\n",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "twtxt.txt")
+
+			if test.file != "" {
+				if err := os.WriteFile(path, []byte(test.file), 0o600); err != nil {
+					t.Fatalf("unexpected error: %q", err)
+				}
+			}
+
+			added, err := Merge(path, test.tweets)
+			if err != nil {
+				t.Fatalf("unexpected error: %q", err)
+			}
+
+			if have, want := added, test.added; have != want {
+				t.Errorf("have %d added, want %d", have, want)
+			}
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("unexpected error: %q", err)
+			}
+
+			if have, want := string(data), test.want; have != want {
+				t.Errorf("have %q, want %q", have, want)
+			}
+
+			if info, err := os.Stat(path); err == nil && test.file != "" && info.Mode().Perm() != 0o600 {
+				t.Errorf("have mode %s, want the mode of the original file", info.Mode())
+			}
+		})
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "twtxt.txt")
+
+		if err := os.WriteFile(path, []byte("not a tweet\n"), 0o644); err != nil {
+			t.Fatalf("unexpected error: %q", err)
+		}
+
+		if _, err := Merge(path, twtxt.Tweets{at(1, "first")}); err == nil {
+			t.Errorf("expected an error")
+		}
+	})
+}
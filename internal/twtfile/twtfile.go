@@ -0,0 +1,80 @@
+package twtfile
+
+import (
+	"bufio"
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"duriny.envs.sh/twtr/internal/atomicfile"
+	"duriny.envs.sh/twtr/twtxt"
+)
+
+// Line is a line of your twtfile, as it is written in the file. The Tweet is
+// nil for comments and blank lines.
+type Line struct {
+	Text  string
+	Tweet *twtxt.Tweet
+}
+
+// Read reads the lines of the twtfile at path, each line that isn't a comment
+// or blank is parsed as a Tweet, and an invalid Tweet is an error, so that a
+// twtfile is never rewritten without it.
+func Read(path string) ([]Line, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make([]Line, 0)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, len(data)+1)
+
+	for scanner.Scan() {
+		line := Line{Text: scanner.Text()}
+
+		if trimmed := strings.TrimSpace(line.Text); trimmed != "" && trimmed[0] != '#' {
+			file, err := twtxt.Parse(strings.NewReader(line.Text))
+			if err != nil {
+				return nil, err
+			}
+
+			if len(file.Tweets) > 0 {
+				line.Tweet = file.Tweets[0]
+			}
+		}
+
+		lines = append(lines, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return lines, nil
+}
+
+// Write replaces the twtfile at path with the lines, see atomicfile.WriteFile.
+// The permissions of the twtfile are kept, and a symlink is followed, so the
+// file that it links to is replaced instead.
+func Write(path string, lines []Line) error {
+	var buf bytes.Buffer
+
+	for _, line := range lines {
+		buf.WriteString(line.Text + "\n")
+	}
+
+	if target, err := filepath.EvalSymlinks(path); err == nil {
+		path = target
+	}
+
+	mode := fs.FileMode(0o644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	return atomicfile.WriteFile(path, buf.Bytes(), mode)
+}
@@ -0,0 +1,79 @@
+package twtfile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadWrite(t *testing.T) {
+	feed := "# nick = alice\n" +
+		"\n" +
+		"2016-02-03T23:05:00+01:00\twelcome to twtxt!\n" +
+		"# a plain comment\n" +
+		"2016-02-04T13:30:00+01:00\tYou can really go crazy here!\\n┐(ﾟ∀ﾟ)┌\n"
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "twtxt.txt")
+
+	if err := os.WriteFile(path, []byte(feed), 0o600); err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	// the twtfile is written through a symlink, e.g. into a public_html
+	link := filepath.Join(dir, "link.txt")
+	if err := os.Symlink(path, link); err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	lines, err := Read(link)
+	if err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	tweets := 0
+	for _, line := range lines {
+		if line.Tweet != nil {
+			tweets++
+		}
+	}
+
+	if have, want := len(lines), 5; have != want {
+		t.Errorf("have %d lines, want %d", have, want)
+	}
+
+	if have, want := tweets, 2; have != want {
+		t.Errorf("have %d tweets, want %d", have, want)
+	}
+
+	if err := Write(link, lines); err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	if have, want := string(data), feed; have != want {
+		t.Errorf("have %q, want %q", have, want)
+	}
+
+	if info, err := os.Lstat(link); err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Errorf("have %v, want the symlink to be kept", info.Mode())
+	}
+
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0o600 {
+		t.Errorf("have mode %v, want the mode of the original file", info.Mode())
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		if err := os.WriteFile(path, []byte("2016-02-03 welcome to twtxt!\n"), 0o600); err != nil {
+			t.Fatalf("unexpected error: %q", err)
+		}
+
+		if _, err := Read(path); err == nil {
+			t.Errorf("expected an error")
+		}
+	})
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"io"
 	"sort"
+	"strings"
+	"time"
 
 	"gopkg.in/ini.v1"
 )
@@ -29,12 +31,118 @@ type Config struct {
 	PreTweetHook           string
 	PostTweetHook          string
 	Following              map[string]string
+	Lists                  map[string][]string
+	Colors                 Colors
+	Filters                map[string]Filter
+	Publish                Publish
+	Gist                   Gist
 }
 
+// Colors holds the styles from the [colors] section of the config, each style
+// is a space separated list of attributes and colors, e.g. "bold blue". An
+// empty style means the default style is used.
+type Colors struct {
+	Nick       string
+	Timestamp  string
+	Mention    string
+	OwnMention string
+	Hashtag    string
+	URL        string
+}
+
+// Publish holds the [publish] section of the config, when a URL is set the
+// twtfile is uploaded to it with an HTTP PUT after each tweet, e.g. to a WebDAV
+// server. The password is read from a separate file, so that the config can be
+// shared without it. Zero Retries means the default number of retries.
+type Publish struct {
+	URL          string
+	Username     string
+	PasswordFile string
+	Retries      int
+}
+
+// Gist holds the [gist] section of the config, when an ID is set the twtfile
+// is hosted in that GitHub gist, and updated after each tweet. The token is
+// read from a separate file, an empty File means "twtxt.txt", and an empty
+// APIURL means the GitHub API.
+type Gist struct {
+	ID        string
+	File      string
+	TokenFile string
+	APIURL    string
+}
+
+// Filter is a rule from the [filters] section of the config, tweets that match
+// the rule are muted until the rule expires. Each rule is written as the type
+// of the rule followed by its pattern, with an optional expiry date.
+//
+//     TYPE PATTERN [until YYYY-MM-DD]
+//
+// The type is one of nick, url, hash, thread, or regex.
+type Filter struct {
+	Type    string
+	Pattern string
+	Expires time.Time
+}
+
+// dateFormat is the format of a Filter expiry date without a time.
+const dateFormat = "2006-01-02"
+
+// ParseFilter parses a Filter from its value in the config.
+func ParseFilter(value string) Filter {
+	var filter Filter
+
+	// split off the type of the rule
+	value = strings.TrimSpace(value)
+	if i := strings.IndexAny(value, " \t"); i < 0 {
+		filter.Type = value
+	} else {
+		filter.Type, filter.Pattern = value[:i], strings.TrimSpace(value[i:])
+	}
+
+	// split off the expiry date, if there is one
+	if i := strings.LastIndex(filter.Pattern, " until "); i >= 0 {
+		date := strings.TrimSpace(filter.Pattern[i+len(" until "):])
+
+		if t, err := time.ParseInLocation(dateFormat, date, time.Local); err == nil {
+			filter.Pattern, filter.Expires = strings.TrimSpace(filter.Pattern[:i]), t
+		} else if t, err := time.Parse(time.RFC3339, date); err == nil {
+			filter.Pattern, filter.Expires = strings.TrimSpace(filter.Pattern[:i]), t
+		}
+	}
+
+	return filter
+}
+
+// String formats the Filter as its value in the config.
+func (f Filter) String() string {
+	value := f.Type + " " + f.Pattern
+
+	switch {
+	case f.Expires.IsZero():
+	case f.Expires.Equal(time.Date(f.Expires.Year(), f.Expires.Month(), f.Expires.Day(), 0, 0, 0, 0, time.Local)):
+		value += " until " + f.Expires.Format(dateFormat)
+	default:
+		value += " until " + f.Expires.Format(time.RFC3339)
+	}
+
+	return value
+}
+
+// Expired reports if the Filter has an expiry date that is not after now.
+func (f Filter) Expired(now time.Time) bool {
+	return !f.Expires.IsZero() && !now.Before(f.Expires)
+}
+
+// loadOptions are the options that the config is read and written with, "#"
+// and ";" only start a comment at the start of a line, like in twtxt, so that
+// they can be used in the values of filters.
+var loadOptions = ini.LoadOptions{IgnoreInlineComment: true}
+
 // New parses a Config for the given reader source. Returns any parsing error
 // that occur.
 func New(source io.Reader) (*Config, error) {
-	file, err := ini.Load(source)
+	file, err := ini.LoadSources(loadOptions, source)
 	if err != nil {
 		return nil, err
 	}
@@ -57,6 +165,8 @@ func New(source io.Reader) (*Config, error) {
 		PreTweetHook:           file.Section("twtxt").Key("pre_tweet_hook").String(),
 		PostTweetHook:          file.Section("twtxt").Key("post_tweet_hook").String(),
 		Following:              make(map[string]string),
+		Lists:                  make(map[string][]string),
+		Filters:                make(map[string]Filter),
 	}
 
 	// get twtxt config section
@@ -72,6 +182,44 @@ func New(source io.Reader) (*Config, error) {
 		cfg.Following[key.Name()] = key.String()
 	}
 
+	// get list config sections, e.g. [list "work"]
+	for _, section := range file.Sections() {
+		if name, ok := listName(section.Name()); ok {
+			cfg.Lists[name] = section.Key("nicks").Strings(",")
+		}
+	}
+
+	// get filters config section
+	for _, key := range file.Section("filters").Keys() {
+		cfg.Filters[key.Name()] = ParseFilter(key.String())
+	}
+
+	// get colors config section
+	cfg.Colors = Colors{
+		Nick:       file.Section("colors").Key("nick").String(),
+		Timestamp:  file.Section("colors").Key("timestamp").String(),
+		Mention:    file.Section("colors").Key("mention").String(),
+		OwnMention: file.Section("colors").Key("own_mention").String(),
+		Hashtag:    file.Section("colors").Key("hashtag").String(),
+		URL:        file.Section("colors").Key("url").String(),
+	}
+
+	// get publish config section
+	cfg.Publish = Publish{
+		URL:          file.Section("publish").Key("url").String(),
+		Username:     file.Section("publish").Key("username").String(),
+		PasswordFile: file.Section("publish").Key("password_file").String(),
+		Retries:      file.Section("publish").Key("retries").MustInt(0),
+	}
+
+	// get gist config section
+	cfg.Gist = Gist{
+		ID:        file.Section("gist").Key("id").String(),
+		File:      file.Section("gist").Key("file").String(),
+		TokenFile: file.Section("gist").Key("token_file").String(),
+		APIURL:    file.Section("gist").Key("api_url").String(),
+	}
+
 	// return config
 	return &cfg, nil
 }
@@ -79,7 +227,7 @@ func New(source io.Reader) (*Config, error) {
 // WriteTo writes an existing config to the given writer, allowing the config to be
 // saved to a file.
 func (c *Config) WriteTo(w io.Writer) (n int64, err error) {
-	file := ini.Empty()
+	file := ini.Empty(loadOptions)
 
 	file.Section("twtxt").Key("nick").SetValue(c.Nick)
 	file.Section("twtxt").Key("twtfile").SetValue(c.Twtfile)
@@ -116,9 +264,113 @@ func (c *Config) WriteTo(w io.Writer) (n int64, err error) {
 		file.Section("following").Key(nick).SetValue(c.Following[nick])
 	}
 
+	i, names := 0, make([]string, len(c.Lists))
+	for name := range c.Lists {
+		names[i] = name
+		i++
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		file.Section(listSection(name)).Key("nicks").SetValue(strings.Join(c.Lists[name], ", "))
+	}
+
+	i, names = 0, make([]string, len(c.Filters))
+	for name := range c.Filters {
+		names[i] = name
+		i++
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		file.Section("filters").Key(name).SetValue(c.Filters[name].String())
+	}
+
+	// only write the colors that have been set
+	colors := [][2]string{
+		{"nick", c.Colors.Nick},
+		{"timestamp", c.Colors.Timestamp},
+		{"mention", c.Colors.Mention},
+		{"own_mention", c.Colors.OwnMention},
+		{"hashtag", c.Colors.Hashtag},
+		{"url", c.Colors.URL},
+	}
+
+	for _, color := range colors {
+		if color[1] != "" {
+			file.Section("colors").Key(color[0]).SetValue(color[1])
+		}
+	}
+
+	// only write the publish section if there is somewhere to publish to
+	if c.Publish.URL != "" {
+		file.Section("publish").Key("url").SetValue(c.Publish.URL)
+		file.Section("publish").Key("username").SetValue(c.Publish.Username)
+		file.Section("publish").Key("password_file").SetValue(c.Publish.PasswordFile)
+
+		if c.Publish.Retries != 0 {
+			file.Section("publish").Key("retries").SetValue(fmt.Sprintf("%v", c.Publish.Retries))
+		}
+	}
+
+	// only write the gist section if there is a gist to host the twtfile
+	if c.Gist.ID != "" {
+		file.Section("gist").Key("id").SetValue(c.Gist.ID)
+		file.Section("gist").Key("token_file").SetValue(c.Gist.TokenFile)
+
+		if c.Gist.File != "" {
+			file.Section("gist").Key("file").SetValue(c.Gist.File)
+		}
+
+		if c.Gist.APIURL != "" {
+			file.Section("gist").Key("api_url").SetValue(c.Gist.APIURL)
+		}
+	}
+
 	if n, err = file.WriteTo(w); err != nil {
 		return
 	}
 
 	return
 }
+
+// List returns the sources that are followed in the named list, as a subset of
+// Following. Returns an error if there is no such list, or if the list contains
+// a nick that is not being followed.
+func (c *Config) List(name string) (map[string]string, error) {
+	nicks, ok := c.Lists[name]
+	if !ok {
+		return nil, fmt.Errorf("no such list: %q", name)
+	}
+
+	following := make(map[string]string, len(nicks))
+
+	for _, nick := range nicks {
+		url, ok := c.Following[nick]
+		if !ok {
+			return nil, fmt.Errorf("list %q: not following %q", name, nick)
+		}
+
+		following[nick] = url
+	}
+
+	return following, nil
+}
+
+// listName is a helper to New(), it returns the name of a list from the name of
+// its config section, reporting false if the section is not a list.
+func listName(section string) (string, bool) {
+	if len(section) < len(`list ""`) || !strings.HasPrefix(section, `list "`) || !strings.HasSuffix(section, `"`) {
+		return "", false
+	}
+
+	return section[len(`list "`) : len(section)-1], true
+}
+
+// listSection is a helper to WriteTo(), it returns the config section name of
+// the named list.
+func listSection(name string) string {
+	return `list "` + name + `"`
+}
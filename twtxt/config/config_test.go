@@ -5,6 +5,7 @@ import (
 	"io"
 	"strings"
 	"testing"
+	"time"
 
 	"duriny.envs.sh/twtr/twtxt/config"
 	"github.com/google/go-cmp/cmp"
@@ -67,6 +68,8 @@ alice = https://example.org/alice.txt
 					"alice": "https://example.org/alice.txt",
 					"bob":   "https://example.org/bob.txt",
 				},
+				Lists:   make(map[string][]string),
+				Filters: make(map[string]config.Filter),
 			},
 		},
 		{
@@ -110,6 +113,166 @@ post_tweet_hook = "scp {twtfile} buckket@example.org:~/public_html/twtxt.txt"
 				PreTweetHook:           "scp buckket@example.org:~/public_html/twtxt.txt {twtfile}",
 				PostTweetHook:          "scp {twtfile} buckket@example.org:~/public_html/twtxt.txt",
 				Following:              make(map[string]string),
+				Lists:                  make(map[string][]string),
+				Filters:                make(map[string]config.Filter),
+			},
+		},
+		{
+			name: "ColorsSection",
+			source: strings.NewReader(`
+[following]
+bob = https://example.org/bob.txt
+
+[colors]
+nick = bold blue
+timestamp = dim
+mention = cyan
+own_mention = bold bright-yellow
+hashtag = magenta
+url = underline 39
+`),
+			want: config.Config{
+				CheckFollowing:         true,
+				UseCache:               true,
+				LimitTimeline:          20,
+				TimelineUpdateInterval: 10,
+				Timeout:                5.0,
+				Following: map[string]string{
+					"bob": "https://example.org/bob.txt",
+				},
+				Lists:   make(map[string][]string),
+				Filters: make(map[string]config.Filter),
+				Colors: config.Colors{
+					Nick:       "bold blue",
+					Timestamp:  "dim",
+					Mention:    "cyan",
+					OwnMention: "bold bright-yellow",
+					Hashtag:    "magenta",
+					URL:        "underline 39",
+				},
+			},
+		},
+		{
+			name: "PublishSection",
+			source: strings.NewReader(`
+[following]
+bob = https://example.org/bob.txt
+
+[publish]
+url           = https://dav.example.org/alice/twtxt.txt
+username      = alice
+password_file = ~/.config/twtxt/dav-password
+retries       = 5
+`),
+			want: config.Config{
+				CheckFollowing:         true,
+				UseCache:               true,
+				LimitTimeline:          20,
+				TimelineUpdateInterval: 10,
+				Timeout:                5.0,
+				Following: map[string]string{
+					"bob": "https://example.org/bob.txt",
+				},
+				Lists:   make(map[string][]string),
+				Filters: make(map[string]config.Filter),
+				Publish: config.Publish{
+					URL:          "https://dav.example.org/alice/twtxt.txt",
+					Username:     "alice",
+					PasswordFile: "~/.config/twtxt/dav-password",
+					Retries:      5,
+				},
+			},
+		},
+		{
+			name: "GistSection",
+			source: strings.NewReader(`
+[gist]
+id         = aa5a315d61ae9438b18d
+file       = alice.txt
+token_file = ~/.config/twtxt/gist-token
+api_url    = http://localhost:8080/api/v3
+`),
+			want: config.Config{
+				CheckFollowing:         true,
+				UseCache:               true,
+				LimitTimeline:          20,
+				TimelineUpdateInterval: 10,
+				Timeout:                5.0,
+				Following:              make(map[string]string),
+				Lists:                  make(map[string][]string),
+				Filters:                make(map[string]config.Filter),
+				Gist: config.Gist{
+					ID:        "aa5a315d61ae9438b18d",
+					File:      "alice.txt",
+					TokenFile: "~/.config/twtxt/gist-token",
+					APIURL:    "http://localhost:8080/api/v3",
+				},
+			},
+		},
+		{
+			name: "ListSections",
+			source: strings.NewReader(`
+[following]
+alice = https://example.org/alice.txt
+bob = https://example.org/bob.txt
+carol = https://example.org/carol.txt
+
+[list "work"]
+nicks = alice, bob
+
+[list "hobby projects"]
+nicks = carol
+
+[list "empty"]
+`),
+			want: config.Config{
+				CheckFollowing:         true,
+				UseCache:               true,
+				LimitTimeline:          20,
+				TimelineUpdateInterval: 10,
+				Timeout:                5.0,
+				Following: map[string]string{
+					"alice": "https://example.org/alice.txt",
+					"bob":   "https://example.org/bob.txt",
+					"carol": "https://example.org/carol.txt",
+				},
+				Lists: map[string][]string{
+					"work":           {"alice", "bob"},
+					"hobby projects": {"carol"},
+					"empty":          {},
+				},
+				Filters: make(map[string]config.Filter),
+			},
+		},
+		{
+			name: "FiltersSection",
+			source: strings.NewReader(`
+[filters]
+newsbot = nick newsbot
+spammer = url https://example.org/spam.txt
+thread  = thread hbdjgiq until 2026-11-01
+twt     = hash 7vmqbxq until 2026-11-01T09:00:00Z
+deploys = regex (?i)deploy(ed|ing)? until further notice
+quoted  = ` + "`regex #spam`" + `
+tagged  = regex (?i)buy now #spam; act fast
+`),
+			want: config.Config{
+				CheckFollowing:         true,
+				UseCache:               true,
+				LimitTimeline:          20,
+				TimelineUpdateInterval: 10,
+				Timeout:                5.0,
+				Following:              make(map[string]string),
+				Lists:                  make(map[string][]string),
+				Filters: map[string]config.Filter{
+					"newsbot": {Type: "nick", Pattern: "newsbot"},
+					"spammer": {Type: "url", Pattern: "https://example.org/spam.txt"},
+					"thread":  {Type: "thread", Pattern: "hbdjgiq", Expires: time.Date(2026, 11, 1, 0, 0, 0, 0, time.Local)},
+					"twt":     {Type: "hash", Pattern: "7vmqbxq", Expires: time.Date(2026, 11, 1, 9, 0, 0, 0, time.UTC)},
+					"deploys": {Type: "regex", Pattern: "(?i)deploy(ed|ing)? until further notice"},
+					"quoted":  {Type: "regex", Pattern: "#spam"},
+					"tagged":  {Type: "regex", Pattern: "(?i)buy now #spam; act fast"},
+				},
 			},
 		},
 		{
@@ -129,6 +292,8 @@ alice = https://example.org/alice.txt
 					"alice": "https://example.org/alice.txt",
 					"bob":   "https://example.org/bob.txt",
 				},
+				Lists:   make(map[string][]string),
+				Filters: make(map[string]config.Filter),
 			},
 		},
 		{
@@ -141,6 +306,8 @@ alice = https://example.org/alice.txt
 				TimelineUpdateInterval: 10,
 				Timeout:                5.0,
 				Following:              make(map[string]string),
+				Lists:                  make(map[string][]string),
+				Filters:                make(map[string]config.Filter),
 			},
 		},
 		{
@@ -160,6 +327,8 @@ samuraiWarrior = "foolish"
 				TimelineUpdateInterval: 10,
 				Timeout:                5.0,
 				Following:              make(map[string]string),
+				Lists:                  make(map[string][]string),
+				Filters:                make(map[string]config.Filter),
 			},
 		},
 		{
@@ -183,6 +352,8 @@ meaningOfLife = 42
 					"meaningOfLife":        "42",
 					"notActuallyANickname": "Not actually a url",
 				},
+				Lists:   make(map[string][]string),
+				Filters: make(map[string]config.Filter),
 			},
 		},
 		{
@@ -275,6 +446,8 @@ alice = https://example.org/alice.txt
 					"alice": "https://example.org/alice.txt",
 					"bob":   "https://example.org/bob.txt",
 				},
+				Lists:   make(map[string][]string),
+				Filters: make(map[string]config.Filter),
 			},
 		},
 	}
@@ -334,6 +507,8 @@ func TestConfigWriteTo(t *testing.T) {
 					"alice": "https://example.org/alice.txt",
 					"bob":   "https://example.org/bob.txt",
 				},
+				Lists:   make(map[string][]string),
+				Filters: make(map[string]config.Filter),
 			},
 			want: `[twtxt]
 nick                     = buckket
@@ -381,6 +556,55 @@ bob   = https://example.org/bob.txt
 				PreTweetHook:           "scp buckket@example.org:~/public_html/twtxt.txt {twtfile}",
 				PostTweetHook:          "scp {twtfile} buckket@example.org:~/public_html/twtxt.txt",
 				Following:              make(map[string]string),
+				Lists:                  make(map[string][]string),
+				Filters:                make(map[string]config.Filter),
+			},
+			want: `[twtxt]
+nick                     = buckket
+twtfile                  = ~/twtxt.txt
+twturl                   = http://example.org/twtxt.txt
+check_following          = true
+use_pager                = false
+use_cache                = true
+porcelain                = false
+disclose_identity        = false
+character_limit          = 140
+character_warning        = 140
+limit_timeline           = 20
+timeline_update_interval = 10
+timeout                  = 5.0
+use_abs_time             = false
+pre_tweet_hook           = scp buckket@example.org:~/public_html/twtxt.txt {twtfile}
+post_tweet_hook          = scp {twtfile} buckket@example.org:~/public_html/twtxt.txt
+sorting                  = descending
+
+`,
+		},
+		{
+			name: "ListSections",
+			from: config.Config{
+				Nick:                   "buckket",
+				Twtfile:                "~/twtxt.txt",
+				Twturl:                 "http://example.org/twtxt.txt",
+				CheckFollowing:         true,
+				UseCache:               true,
+				CharacterLimit:         140,
+				CharacterWarning:       140,
+				LimitTimeline:          20,
+				TimelineUpdateInterval: 10,
+				Timeout:                5.0,
+				PreTweetHook:           "scp buckket@example.org:~/public_html/twtxt.txt {twtfile}",
+				PostTweetHook:          "scp {twtfile} buckket@example.org:~/public_html/twtxt.txt",
+				Following: map[string]string{
+					"alice": "https://example.org/alice.txt",
+					"bob":   "https://example.org/bob.txt",
+					"carol": "https://example.org/carol.txt",
+				},
+				Lists: map[string][]string{
+					"work":           {"alice", "bob"},
+					"hobby projects": {"carol"},
+				},
+				Filters: make(map[string]config.Filter),
 			},
 			want: `[twtxt]
 nick                     = buckket
@@ -401,6 +625,216 @@ pre_tweet_hook           = scp buckket@example.org:~/public_html/twtxt.txt {twtf
 post_tweet_hook          = scp {twtfile} buckket@example.org:~/public_html/twtxt.txt
 sorting                  = descending
 
+[following]
+alice = https://example.org/alice.txt
+bob   = https://example.org/bob.txt
+carol = https://example.org/carol.txt
+
+[list "hobby projects"]
+nicks = carol
+
+[list "work"]
+nicks = alice, bob
+
+`,
+		},
+		{
+			name: "FiltersSection",
+			from: config.Config{
+				Nick:                   "buckket",
+				Twtfile:                "~/twtxt.txt",
+				Twturl:                 "http://example.org/twtxt.txt",
+				CheckFollowing:         true,
+				UseCache:               true,
+				CharacterLimit:         140,
+				CharacterWarning:       140,
+				LimitTimeline:          20,
+				TimelineUpdateInterval: 10,
+				Timeout:                5.0,
+				PreTweetHook:           "scp buckket@example.org:~/public_html/twtxt.txt {twtfile}",
+				PostTweetHook:          "scp {twtfile} buckket@example.org:~/public_html/twtxt.txt",
+				Following:              make(map[string]string),
+				Lists:                  make(map[string][]string),
+				Filters: map[string]config.Filter{
+					"newsbot": {Type: "nick", Pattern: "newsbot"},
+					"thread":  {Type: "thread", Pattern: "hbdjgiq", Expires: time.Date(2026, 11, 1, 0, 0, 0, 0, time.Local)},
+					"twt":     {Type: "hash", Pattern: "7vmqbxq", Expires: time.Date(2026, 11, 1, 9, 0, 0, 0, time.UTC)},
+					"spam":    {Type: "regex", Pattern: "#spam; now"},
+				},
+			},
+			want: `[twtxt]
+nick                     = buckket
+twtfile                  = ~/twtxt.txt
+twturl                   = http://example.org/twtxt.txt
+check_following          = true
+use_pager                = false
+use_cache                = true
+porcelain                = false
+disclose_identity        = false
+character_limit          = 140
+character_warning        = 140
+limit_timeline           = 20
+timeline_update_interval = 10
+timeout                  = 5.0
+use_abs_time             = false
+pre_tweet_hook           = scp buckket@example.org:~/public_html/twtxt.txt {twtfile}
+post_tweet_hook          = scp {twtfile} buckket@example.org:~/public_html/twtxt.txt
+sorting                  = descending
+
+[filters]
+newsbot = nick newsbot
+spam    = regex #spam; now
+thread  = thread hbdjgiq until 2026-11-01
+twt     = hash 7vmqbxq until 2026-11-01T09:00:00Z
+
+`,
+		},
+		{
+			name: "ColorsSection",
+			from: config.Config{
+				Nick:                   "buckket",
+				Twtfile:                "~/twtxt.txt",
+				Twturl:                 "http://example.org/twtxt.txt",
+				CheckFollowing:         true,
+				UseCache:               true,
+				LimitTimeline:          20,
+				TimelineUpdateInterval: 10,
+				Timeout:                5.0,
+				PreTweetHook:           "scp buckket@example.org:~/public_html/twtxt.txt {twtfile}",
+				PostTweetHook:          "scp {twtfile} buckket@example.org:~/public_html/twtxt.txt",
+				Following: map[string]string{
+					"bob": "https://example.org/bob.txt",
+				},
+				Lists:   make(map[string][]string),
+				Filters: make(map[string]config.Filter),
+				Colors: config.Colors{
+					Nick:       "bold blue",
+					OwnMention: "bold bright-yellow",
+					URL:        "underline 39",
+				},
+			},
+			want: `[twtxt]
+nick                     = buckket
+twtfile                  = ~/twtxt.txt
+twturl                   = http://example.org/twtxt.txt
+check_following          = true
+use_pager                = false
+use_cache                = true
+porcelain                = false
+disclose_identity        = false
+character_limit          = 0
+character_warning        = 0
+limit_timeline           = 20
+timeline_update_interval = 10
+timeout                  = 5.0
+use_abs_time             = false
+pre_tweet_hook           = scp buckket@example.org:~/public_html/twtxt.txt {twtfile}
+post_tweet_hook          = scp {twtfile} buckket@example.org:~/public_html/twtxt.txt
+sorting                  = descending
+
+[following]
+bob = https://example.org/bob.txt
+
+[colors]
+nick        = bold blue
+own_mention = bold bright-yellow
+url         = underline 39
+
+`,
+		},
+		{
+			name: "PublishSection",
+			from: config.Config{
+				Nick:                   "buckket",
+				Twtfile:                "~/twtxt.txt",
+				Twturl:                 "http://example.org/twtxt.txt",
+				CheckFollowing:         true,
+				UseCache:               true,
+				LimitTimeline:          20,
+				TimelineUpdateInterval: 10,
+				Timeout:                5.0,
+				Following: map[string]string{
+					"bob": "https://example.org/bob.txt",
+				},
+				Lists:   make(map[string][]string),
+				Filters: make(map[string]config.Filter),
+				Publish: config.Publish{
+					URL:          "https://dav.example.org/buckket/twtxt.txt",
+					Username:     "buckket",
+					PasswordFile: "~/.config/twtxt/dav-password",
+				},
+			},
+			want: `[twtxt]
+nick                     = buckket
+twtfile                  = ~/twtxt.txt
+twturl                   = http://example.org/twtxt.txt
+check_following          = true
+use_pager                = false
+use_cache                = true
+porcelain                = false
+disclose_identity        = false
+character_limit          = 0
+character_warning        = 0
+limit_timeline           = 20
+timeline_update_interval = 10
+timeout                  = 5.0
+use_abs_time             = false
+pre_tweet_hook           = 
+post_tweet_hook          = 
+sorting                  = descending
+
+[following]
+bob = https://example.org/bob.txt
+
+[publish]
+url           = https://dav.example.org/buckket/twtxt.txt
+username      = buckket
+password_file = ~/.config/twtxt/dav-password
+
+`,
+		},
+		{
+			name: "GistSection",
+			from: config.Config{
+				Nick:                   "buckket",
+				Twtfile:                "~/twtxt.txt",
+				Twturl:                 "https://gist.githubusercontent.com/buckket/aa5a315d61ae9438b18d/raw/twtxt.txt",
+				CheckFollowing:         true,
+				UseCache:               true,
+				LimitTimeline:          20,
+				TimelineUpdateInterval: 10,
+				Timeout:                5.0,
+				Following:              make(map[string]string),
+				Lists:                  make(map[string][]string),
+				Filters:                make(map[string]config.Filter),
+				Gist: config.Gist{
+					ID:        "aa5a315d61ae9438b18d",
+					TokenFile: "~/.config/twtxt/gist-token",
+				},
+			},
+			want: `[twtxt]
+nick                     = buckket
+twtfile                  = ~/twtxt.txt
+twturl                   = https://gist.githubusercontent.com/buckket/aa5a315d61ae9438b18d/raw/twtxt.txt
+check_following          = true
+use_pager                = false
+use_cache                = true
+porcelain                = false
+disclose_identity        = false
+character_limit          = 0
+character_warning        = 0
+limit_timeline           = 20
+timeline_update_interval = 10
+timeout                  = 5.0
+use_abs_time             = false
+pre_tweet_hook           = 
+post_tweet_hook          = 
+sorting                  = descending
+
+[gist]
+id         = aa5a315d61ae9438b18d
+token_file = ~/.config/twtxt/gist-token
+
 `,
 		},
 	}
@@ -439,3 +873,113 @@ sorting                  = descending
 		})
 	}
 }
+
+func TestConfigList(t *testing.T) {
+	cfg := config.Config{
+		Following: map[string]string{
+			"alice": "https://example.org/alice.txt",
+			"bob":   "https://example.org/bob.txt",
+			"carol": "https://example.org/carol.txt",
+		},
+		Lists: map[string][]string{
+			"work":    {"alice", "bob"},
+			"ghosts":  {"alice", "dave"},
+			"nothing": {},
+		},
+		Filters: make(map[string]config.Filter),
+	}
+
+	tests := []struct {
+		name string
+		want map[string]string
+		err  bool
+	}{
+		{
+			name: "work",
+			want: map[string]string{
+				"alice": "https://example.org/alice.txt",
+				"bob":   "https://example.org/bob.txt",
+			},
+		},
+		{
+			name: "nothing",
+			want: map[string]string{},
+		},
+		{
+			name: "ghosts",
+			err:  true,
+		},
+		{
+			name: "missing",
+			err:  true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			have, err := cfg.List(test.name)
+
+			if (err != nil) != test.err {
+				t.Fatalf("err = %v, want error %t", err, test.err)
+			}
+
+			if diff := cmp.Diff(have, test.want); diff != "" {
+				t.Errorf("diff:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFilter(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value   string
+		filter  config.Filter
+		expired bool
+	}{
+		{
+			value:  "nick newsbot",
+			filter: config.Filter{Type: "nick", Pattern: "newsbot"},
+		},
+		{
+			value:  "regex (?i)buy now",
+			filter: config.Filter{Type: "regex", Pattern: "(?i)buy now"},
+		},
+		{
+			value:   "thread hbdjgiq until 2026-10-19T12:00:00Z",
+			filter:  config.Filter{Type: "thread", Pattern: "hbdjgiq", Expires: now},
+			expired: true,
+		},
+		{
+			value:  "thread hbdjgiq until 2026-10-19T12:00:01Z",
+			filter: config.Filter{Type: "thread", Pattern: "hbdjgiq", Expires: now.Add(time.Second)},
+		},
+		{
+			value:  "nick",
+			filter: config.Filter{Type: "nick"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.value, func(t *testing.T) {
+			filter := config.ParseFilter(test.value)
+
+			if diff := cmp.Diff(filter, test.filter); diff != "" {
+				t.Errorf("diff:\n%s", diff)
+			}
+
+			if have, want := filter.Expired(now), test.expired; have != want {
+				t.Errorf("Expired() = %t, want %t", have, want)
+			}
+
+			if have := config.ParseFilter(filter.String()); !cmp.Equal(have, filter) {
+				t.Errorf("String() = %q, does not parse back to %v", filter.String(), filter)
+			}
+		})
+	}
+}
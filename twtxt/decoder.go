@@ -0,0 +1,275 @@
+package twtxt
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"time"
+)
+
+// errSkipped is returned by readLine when a line was skipped, see Skipped.
+var errSkipped = errors.New("line skipped")
+
+// Decoder reads a twtxt feed from a source one line at a time, decoding the
+// metadata Fields and Tweets as they are found, so that large feeds can be
+// processed without holding the entire feed in memory.
+//
+// Decoder is used in a similar manner to a bufio.Scanner, Next is called to
+// advance to the next Field or Tweet until it returns false, and then Err
+// reports any error that stopped the decoding.
+//
+//     dec := twtxt.NewDecoder(source)
+//
+//     for dec.Next() {
+//             if tweet := dec.Tweet(); tweet != nil {
+//                     fmt.Println(tweet.Text())
+//             }
+//     }
+//
+//     if err := dec.Err(); err != nil {
+//             log.Fatal(err)
+//     }
+type Decoder struct {
+	// MaxLineLength is the maximum length in bytes of a single line in the
+	// feed, not including the line ending. A line longer than this is skipped,
+	// see Skipped. If MaxLineLength is zero, or less, there is no limit.
+	MaxLineLength int
+
+	reader  *bufio.Reader
+	buffer  []byte
+	line    uint64
+	field   *Field
+	tweet   *Tweet
+	err     error
+	skipped []*ParseError
+	block   bool // allocate Tweets in blocks, see newTweet
+	tweets  []Tweet
+	zones   map[int]*time.Location
+}
+
+// NewDecoder creates a new Decoder that reads from the given source. If the
+// source is nil, then there aren't any Fields or Tweets to decode.
+func NewDecoder(source io.Reader) *Decoder {
+	dec := &Decoder{}
+
+	if source != nil {
+		dec.reader = bufio.NewReader(source)
+	}
+
+	return dec
+}
+
+// Next advances the Decoder to the next Field or Tweet in the feed, skipping
+// over any plain comments. Next returns false when the end of the feed is
+// reached, or when an error occurs, in which case Err returns the error.
+func (dec *Decoder) Next() bool {
+	dec.field, dec.tweet = nil, nil
+
+	for dec.err == nil && dec.reader != nil {
+		line, err := dec.readLine()
+
+		// the end of the source is not an error
+		if err == io.EOF {
+			return false
+		}
+
+		if err == errSkipped {
+			continue
+		}
+
+		if err != nil {
+			dec.err = err
+			return false
+		}
+
+		// comments are either metadata fields or ignored
+		if len(line) > 0 && line[0] == '#' {
+			if field := parseField(line); field != nil {
+				dec.field = field
+				return true
+			}
+
+			continue
+		}
+
+		// everything else has to be a tweet
+		tweet, perr := dec.parseTweet(line)
+
+		// catch any parse errors
+		if perr != nil {
+			perr.line = dec.line
+			dec.err = perr
+			return false
+		}
+
+		dec.tweet = tweet
+		return true
+	}
+
+	return false
+}
+
+// Field returns the metadata Field found by the last call to Next, or nil if
+// the last call to Next found a Tweet.
+func (dec *Decoder) Field() *Field {
+	return dec.field
+}
+
+// Tweet returns the Tweet found by the last call to Next, or nil if the last
+// call to Next found a metadata Field.
+func (dec *Decoder) Tweet() *Tweet {
+	return dec.tweet
+}
+
+// Err returns the first error that was encountered by the Decoder, reaching
+// the end of the feed is not considered to be an error.
+func (dec *Decoder) Err() error {
+	return dec.err
+}
+
+// Skipped returns a ParseError for each line that was skipped so far, because
+// it was longer than the MaxLineLength. Skipped lines are not an error, the
+// Decoder carries on with the next line.
+func (dec *Decoder) Skipped() []*ParseError {
+	return dec.skipped
+}
+
+// Decode reads the rest of the feed into a File, it is the same as Parse, but
+// with the MaxLineLength of the Decoder, see Skipped for any skipped lines.
+func (dec *Decoder) Decode() (*File, error) {
+	file := &File{
+		make(Fields, 0),
+		make(Tweets, 0),
+	}
+
+	// every Tweet is kept, so none of a block is retained needlessly
+	dec.block = true
+	defer func() { dec.block, dec.tweets = false, nil }()
+
+	// decode each line into a Field or Tweet
+	for dec.Next() {
+		if field := dec.Field(); field != nil {
+			file.Fields = append(file.Fields, field)
+		}
+
+		if tweet := dec.Tweet(); tweet != nil {
+			file.Tweets = append(file.Tweets, tweet)
+		}
+	}
+
+	// catch any parse or reading errors
+	if err := dec.Err(); err != nil {
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// readLine is a helper to Next(), it reads the next line from the source with
+// the line ending removed. The returned slice is only valid until the next
+// call to readLine. Returns io.EOF once there are no more lines, and errSkipped
+// if the line was too long.
+func (dec *Decoder) readLine() ([]byte, error) {
+	line, err := dec.reader.ReadSlice('\n')
+
+	// lines that don't fit in the reader's buffer are collected separately
+	if err == bufio.ErrBufferFull {
+		dec.buffer = append(dec.buffer[:0], line...)
+
+		for err == bufio.ErrBufferFull {
+			// catch lines that are too long before reading any more of them
+			if dec.tooLong(bytes.TrimSuffix(dec.buffer, []byte("\r"))) {
+				return nil, dec.skipLine()
+			}
+
+			line, err = dec.reader.ReadSlice('\n')
+			dec.buffer = append(dec.buffer, line...)
+		}
+
+		line = dec.buffer
+	}
+
+	// there is no line if the source is finished
+	if err == io.EOF && len(line) == 0 {
+		return nil, io.EOF
+	}
+
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	dec.line++
+
+	// drop the line ending
+	line = bytes.TrimSuffix(line, []byte("\n"))
+	line = bytes.TrimSuffix(line, []byte("\r"))
+
+	if dec.tooLong(line) {
+		dec.skipped = append(dec.skipped, &ParseError{line: dec.line, msg: "line too long"})
+		return nil, errSkipped
+	}
+
+	return line, nil
+}
+
+// skipLine is a helper to readLine(), it discards the rest of a line that is
+// too long without collecting it, and records it as skipped.
+func (dec *Decoder) skipLine() error {
+	_, err := dec.reader.ReadSlice('\n')
+
+	for err == bufio.ErrBufferFull {
+		_, err = dec.reader.ReadSlice('\n')
+	}
+
+	if err != nil && err != io.EOF {
+		return err
+	}
+
+	dec.line++
+	dec.skipped = append(dec.skipped, &ParseError{line: dec.line, msg: "line too long"})
+
+	return errSkipped
+}
+
+// tooLong is a helper to readLine(), it reports if the line is longer than
+// the MaxLineLength.
+func (dec *Decoder) tooLong(line []byte) bool {
+	return dec.MaxLineLength > 0 && len(line) > dec.MaxLineLength
+}
+
+// newTweet is a helper to parseTweet(), when decoding a whole feed Tweets are
+// allocated in blocks rather than one at a time, which saves many small
+// allocations on large feeds. A block is only freed once none of its Tweets
+// are used, so Next allocates each Tweet on its own, as a caller that streams
+// a feed may keep only a few of its Tweets.
+func (dec *Decoder) newTweet() *Tweet {
+	if !dec.block {
+		return &Tweet{}
+	}
+
+	if len(dec.tweets) == 0 {
+		dec.tweets = make([]Tweet, 64)
+	}
+
+	tweet := &dec.tweets[0]
+	dec.tweets = dec.tweets[1:]
+
+	return tweet
+}
+
+// zone is a helper to parseTimestampFast(), it returns a time.Location for
+// the offset, reusing the same Location for every timestamp with that offset.
+func (dec *Decoder) zone(offset int) *time.Location {
+	if dec.zones == nil {
+		dec.zones = make(map[int]*time.Location)
+	}
+
+	loc, ok := dec.zones[offset]
+	if !ok {
+		loc = time.FixedZone("", offset)
+		dec.zones[offset] = loc
+	}
+
+	return loc
+}
@@ -0,0 +1,327 @@
+package twtxt
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDecoder(t *testing.T) {
+	long := strings.Repeat("a", 128*1024)
+
+	tests := []struct {
+		name    string
+		source  io.Reader
+		max     int
+		fields  Fields
+		tweets  Tweets
+		skipped []*ParseError
+		err     error
+	}{
+		{
+			name:   "Nil",
+			source: nil,
+		},
+		{
+			name:   "Empty",
+			source: strings.NewReader(""),
+		},
+		{
+			name: "MixedFieldsCommentsAndTweets",
+			source: strings.NewReader(strings.Join([]string{
+				"# this is a comment",
+				"# nick = buckket",
+				"2016-02-03T23:05:00+01:00\t@<example http://example.org/twtxt.txt> welcome to twtxt!",
+				"# url = https://example.org/twtxt.txt",
+				"2015-12-12T12:00:00+01:00\tFiat lux!",
+			}, "\n")),
+			fields: Fields{
+				&Field{
+					key: "nick",
+					val: "buckket",
+				},
+				&Field{
+					key: "url",
+					val: "https://example.org/twtxt.txt",
+				},
+			},
+			tweets: Tweets{
+				&Tweet{
+					time: time.Date(2016, 2, 3, 23, 5, 0, 0, loc(+1)),
+					post: "@<example http://example.org/twtxt.txt> welcome to twtxt!",
+				},
+				&Tweet{
+					time: time.Date(2015, 12, 12, 12, 0, 0, 0, loc(+1)),
+					post: "Fiat lux!",
+				},
+			},
+		},
+		{
+			name: "CarriageReturns",
+			source: strings.NewReader(strings.Join([]string{
+				"# nick = buckket",
+				"2015-12-12T12:00:00+01:00\tFiat lux!",
+				"",
+			}, "\r\n")),
+			fields: Fields{
+				&Field{
+					key: "nick",
+					val: "buckket",
+				},
+			},
+			tweets: Tweets{
+				&Tweet{
+					time: time.Date(2015, 12, 12, 12, 0, 0, 0, loc(+1)),
+					post: "Fiat lux!",
+				},
+			},
+		},
+		{
+			name:   "LongLineWithoutLimit",
+			source: strings.NewReader("2015-12-12T12:00:00+01:00\t" + long),
+			tweets: Tweets{
+				&Tweet{
+					time: time.Date(2015, 12, 12, 12, 0, 0, 0, loc(+1)),
+					post: long,
+				},
+			},
+		},
+		{
+			name:   "LongLineWithinLimit",
+			max:    64,
+			source: strings.NewReader("2015-12-12T12:00:00+01:00\tFiat lux!"),
+			tweets: Tweets{
+				&Tweet{
+					time: time.Date(2015, 12, 12, 12, 0, 0, 0, loc(+1)),
+					post: "Fiat lux!",
+				},
+			},
+		},
+		{
+			name: "LongLineOverLimit",
+			max:  64 * 1024,
+			source: strings.NewReader(strings.Join([]string{
+				"# nick = buckket",
+				"2015-12-12T12:00:00+01:00\tFiat lux!",
+				"2015-12-12T12:00:00+01:00\t" + long,
+				"2016-02-04T13:30:00+01:00\tYou can really go crazy here!",
+			}, "\n")),
+			fields: Fields{
+				&Field{
+					key: "nick",
+					val: "buckket",
+				},
+			},
+			tweets: Tweets{
+				&Tweet{
+					time: time.Date(2015, 12, 12, 12, 0, 0, 0, loc(+1)),
+					post: "Fiat lux!",
+				},
+				&Tweet{
+					time: time.Date(2016, 2, 4, 13, 30, 0, 0, loc(+1)),
+					post: "You can really go crazy here!",
+				},
+			},
+			skipped: []*ParseError{
+				{line: 3, msg: "line too long"},
+			},
+		},
+		{
+			name: "ShortLinesOverLimit",
+			max:  45,
+			source: strings.NewReader(strings.Join([]string{
+				"2015-12-12T12:00:00+01:00\tFiat lux!",
+				"2016-02-04T13:30:00+01:00\tYou can really go crazy here!",
+				"# this comment is too long to be read, but it is skipped",
+				"2016-02-01T11:00:00+01:00\tAnother example.",
+				"2015-12-12T12:00:00+01:00\t" + long,
+			}, "\n")),
+			tweets: Tweets{
+				&Tweet{
+					time: time.Date(2015, 12, 12, 12, 0, 0, 0, loc(+1)),
+					post: "Fiat lux!",
+				},
+				&Tweet{
+					time: time.Date(2016, 2, 1, 11, 0, 0, 0, loc(+1)),
+					post: "Another example.",
+				},
+			},
+			skipped: []*ParseError{
+				{line: 2, msg: "line too long"},
+				{line: 3, msg: "line too long"},
+				{line: 5, msg: "line too long"},
+			},
+		},
+		{
+			name: "MissingTabDelimiter",
+			source: strings.NewReader(strings.Join([]string{
+				"# nick = buckket",
+				"2015-12-12T12:00:00+01:00 Fiat lux!",
+			}, "\n")),
+			fields: Fields{
+				&Field{
+					key: "nick",
+					val: "buckket",
+				},
+			},
+			err: &ParseError{
+				line: 2,
+				msg:  "missing tab delimiter",
+			},
+		},
+		{
+			name:   "BadReader",
+			source: iotest.ErrReader(errors.New("bad reader")),
+			err:    errors.New("bad reader"),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			var fields Fields
+			var tweets Tweets
+
+			dec := NewDecoder(test.source)
+			dec.MaxLineLength = test.max
+
+			for dec.Next() {
+				field, tweet := dec.Field(), dec.Tweet()
+
+				// exactly one of the field or tweet should be set
+				if (field == nil) == (tweet == nil) {
+					t.Fatalf("have field %v and tweet %v, want exactly one", field, tweet)
+				}
+
+				if field != nil {
+					fields = append(fields, field)
+				}
+
+				if tweet != nil {
+					tweets = append(tweets, tweet)
+				}
+			}
+
+			// decoded fields should match the expected fields
+			if diff := cmp.Diff(fields, test.fields, cmp.AllowUnexported(Field{})); diff != "" {
+				t.Errorf("diff:\n%s", diff)
+			}
+
+			// decoded tweets should match the expected tweets
+			if diff := cmp.Diff(tweets, test.tweets, cmp.AllowUnexported(Tweet{})); diff != "" {
+				t.Errorf("diff:\n%s", diff)
+			}
+
+			// skipped lines should match the expected skipped lines
+			if diff := cmp.Diff(dec.Skipped(), test.skipped, cmp.AllowUnexported(ParseError{})); diff != "" {
+				t.Errorf("diff:\n%s", diff)
+			}
+
+			// error should match expected err
+			switch have, want := dec.Err(), test.err; {
+			case have == nil && want == nil:
+			case have == nil || want == nil:
+				t.Errorf("have %v, want %v", have, want)
+			case have.Error() != want.Error():
+				t.Errorf("have %q, want %q", have, want)
+			}
+
+			// the decoder should stay finished
+			if dec.Next() {
+				t.Error("want Next() to return false after the end of the feed")
+			}
+		})
+	}
+}
+
+func TestDecoderDecode(t *testing.T) {
+	dec := NewDecoder(strings.NewReader(strings.Join([]string{
+		"# nick = buckket",
+		"2015-12-12T12:00:00+01:00\t" + strings.Repeat("a", 1024),
+		"2016-02-04T13:30:00+01:00\tFiat lux!",
+	}, "\n")))
+	dec.MaxLineLength = 512
+
+	file, err := dec.Decode()
+	if err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	if have := len(file.Tweets); have != 1 || file.Tweets[0].Text() != "Fiat lux!" {
+		t.Errorf("have %d tweets, want %q", have, "Fiat lux!")
+	}
+
+	if have := len(dec.Skipped()); have != 1 {
+		t.Errorf("have %d skipped lines, want 1", have)
+	}
+}
+
+func TestDecoderTimestamps(t *testing.T) {
+	tests := []string{
+		"2016-02-03T23:05:00+01:00",
+		"2016-02-03T23:05:00-05:30",
+		"2016-02-03T23:05:00+00:00",
+		"2016-02-03T23:05:00-00:00",
+		"2016-02-03T23:05:00Z",
+		"2016-02-29T23:59:59+13:00",
+		"2016-02-03T23:05:00.123456+01:00",
+		"2016-02-03t23:05:00z",
+		"2017-02-29T23:05:00+01:00",
+		"2016-02-74T23:05:00+01:00",
+		"2016-13-01T11:00:60+01:00",
+		"2015-12-12T12:00:00+99:00",
+		"2016-02-04T60:30:00+01:00",
+		"2016-02-04 13:30:00+01:00",
+		"2016-02-04T13:30:00",
+		"+016-02-04T13:30:00+01:00",
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test, func(t *testing.T) {
+			var dec Decoder
+
+			have, haveErr := dec.parseTimestamp([]byte(test))
+			want, wantErr := time.Parse(time.RFC3339, test)
+
+			if (haveErr == nil) != (wantErr == nil) {
+				t.Fatalf("have error %v, want error %v", haveErr, wantErr)
+			}
+
+			if !have.Equal(want) {
+				t.Errorf("\nhave: %s\nwant: %s", have, want)
+			}
+
+			if have.Format(time.RFC3339Nano) != want.Format(time.RFC3339Nano) {
+				t.Errorf("\nhave: %s\nwant: %s", have.Format(time.RFC3339Nano), want.Format(time.RFC3339Nano))
+			}
+		})
+	}
+}
+
+func BenchmarkDecoder(b *testing.B) {
+	feed := benchmarkFeed(10 * 1024 * 1024)
+
+	b.SetBytes(int64(len(feed)))
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		dec := NewDecoder(bytes.NewReader(feed))
+
+		for dec.Next() {
+		}
+
+		if err := dec.Err(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
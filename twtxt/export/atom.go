@@ -0,0 +1,98 @@
+package export
+
+import (
+	"encoding/xml"
+	"io"
+	"time"
+)
+
+// atomFeed is the root element of an Atom feed, see RFC 4287.
+type atomFeed struct {
+	XMLName  xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID       string      `xml:"id"`
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle,omitempty"`
+	Updated  string      `xml:"updated"`
+	Icon     string      `xml:"icon,omitempty"`
+	Author   *atomAuthor `xml:"author,omitempty"`
+	Links    []atomLink  `xml:"link"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+	URI  string `xml:"uri,omitempty"`
+}
+
+type atomLink struct {
+	Href  string `xml:"href,attr"`
+	Rel   string `xml:"rel,attr,omitempty"`
+	Type  string `xml:"type,attr,omitempty"`
+	Title string `xml:"title,attr,omitempty"`
+}
+
+type atomEntry struct {
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Author  *atomAuthor `xml:"author"`
+	Content atomContent `xml:"content"`
+}
+
+type atomContent struct {
+	Type string `xml:"type,attr"`
+	Text string `xml:",chardata"`
+}
+
+// Atom writes the Feed as an Atom feed.
+func (feed *Feed) Atom(w io.Writer) error {
+	atom := atomFeed{
+		ID:       feed.URL,
+		Title:    feed.Title,
+		Subtitle: feed.Description,
+		Updated:  feed.Updated.Format(time.RFC3339),
+		Icon:     feed.Avatar,
+		Links: []atomLink{
+			{Href: feed.URL, Rel: "alternate", Type: "text/plain"},
+		},
+	}
+
+	if feed.Author != "" {
+		atom.Author = &atomAuthor{Name: feed.Author, URI: feed.URL}
+	}
+
+	for _, link := range feed.Links {
+		atom.Links = append(atom.Links, atomLink{Href: link.URL, Rel: "related", Title: link.Text})
+	}
+
+	for _, item := range feed.sorted() {
+		atom.Entries = append(atom.Entries, atomEntry{
+			ID:      item.ID(),
+			Title:   item.Title(),
+			Updated: item.Time.Format(time.RFC3339),
+			Author:  &atomAuthor{Name: item.Author, URI: item.URL},
+			Content: atomContent{Type: "text", Text: item.Text},
+		})
+	}
+
+	return writeXML(w, atom)
+}
+
+// writeXML is a helper to the XML exporters, it writes the XML declaration and
+// the indented document.
+func writeXML(w io.Writer, v interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+
+	return err
+}
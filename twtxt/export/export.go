@@ -0,0 +1,162 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"duriny.envs.sh/twtr/twtxt"
+)
+
+// Feed is a twtxt feed, or a timeline of many feeds, ready to be exported as
+// an Atom, RSS, or JSON Feed.
+type Feed struct {
+	Title       string
+	URL         string // the url of the twtxt feed
+	Author      string
+	Avatar      string
+	Description string
+	Links       []Link
+	Updated     time.Time
+	Items       []Item
+}
+
+// Link is a link from a "# link = TEXT URL" metadata field.
+type Link struct {
+	Text string
+	URL  string
+}
+
+// Item is a single Tweet of a Feed, it is identified by its twt hash, which is
+// stable for as long as the Tweet is not edited.
+type Item struct {
+	Hash   string
+	Author string
+	URL    string // the url of the twtxt feed that the Tweet was posted in
+	Time   time.Time
+	Text   string
+}
+
+// ID returns the unique identifier of the Item, the url of its feed with the
+// twt hash as a fragment, e.g. "https://example.org/twtxt.txt#abcdefg".
+func (item Item) ID() string {
+	return item.URL + "#" + item.Hash
+}
+
+// Title returns a short title for the Item, the first line of its text.
+func (item Item) Title() string {
+	title := strings.TrimSpace(strings.SplitN(item.Text, "\n", 2)[0])
+
+	if runes := []rune(title); len(runes) > titleLength {
+		title = strings.TrimSpace(string(runes[:titleLength-1])) + "…"
+	}
+
+	return title
+}
+
+// titleLength is the maximum number of characters in the title of an Item.
+const titleLength = 80
+
+// New creates a Feed from a twtxt file that is hosted at url. The nick, avatar,
+// description, and link metadata fields are mapped to the Feed, the nick falls
+// back to the given nick, e.g. the nick that the feed is followed by.
+func New(nick, url string, file *twtxt.File) *Feed {
+	feed := &Feed{
+		URL:    url,
+		Author: nick,
+	}
+
+	if fields := file.Fields.Search("nick"); len(fields) > 0 {
+		feed.Author = fields[0].Value()
+	}
+
+	if fields := file.Fields.Search("avatar"); len(fields) > 0 {
+		feed.Avatar = fields[0].Value()
+	}
+
+	if fields := file.Fields.Search("description"); len(fields) > 0 {
+		feed.Description = fields[0].Value()
+	}
+
+	for _, field := range file.Fields.Search("link") {
+		feed.Links = append(feed.Links, parseLink(field.Value()))
+	}
+
+	feed.Title = feed.Author
+	if feed.Title == "" {
+		feed.Title = url
+	}
+
+	for _, tweet := range file.Tweets {
+		feed.Add(Item{
+			Hash:   tweet.Hash(url),
+			Author: feed.Author,
+			URL:    url,
+			Time:   tweet.Time(),
+			Text:   tweet.Text(),
+		})
+	}
+
+	return feed
+}
+
+// parseLink is a helper to New(), it splits the value of a link field into its
+// text and url, the url is the last word of the value.
+func parseLink(value string) Link {
+	value = strings.TrimSpace(value)
+
+	i := strings.LastIndexAny(value, " \t")
+	if i < 0 {
+		return Link{Text: value, URL: value}
+	}
+
+	return Link{Text: strings.TrimSpace(value[:i]), URL: value[i+1:]}
+}
+
+// Add adds an Item to the Feed, and keeps Updated at the time of the newest
+// Item. Items are exported newest first, whatever order they were added in.
+func (feed *Feed) Add(item Item) {
+	feed.Items = append(feed.Items, item)
+
+	if item.Time.After(feed.Updated) {
+		feed.Updated = item.Time
+	}
+}
+
+// sorted is a helper to the exporters, it returns the Items newest first.
+func (feed *Feed) sorted() []Item {
+	items := make([]Item, len(feed.Items))
+	copy(items, feed.Items)
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Time.After(items[j].Time)
+	})
+
+	return items
+}
+
+// Formats are the formats that a Feed can be exported as.
+var Formats = []string{"atom", "rss", "jsonfeed"}
+
+// ContentTypes are the content types of each of the Formats.
+var ContentTypes = map[string]string{
+	"atom":     "application/atom+xml; charset=utf-8",
+	"rss":      "application/rss+xml; charset=utf-8",
+	"jsonfeed": "application/feed+json; charset=utf-8",
+}
+
+// Write writes the Feed in the named format, one of Formats.
+func (feed *Feed) Write(w io.Writer, format string) error {
+	switch format {
+	case "atom":
+		return feed.Atom(w)
+	case "rss":
+		return feed.RSS(w)
+	case "jsonfeed":
+		return feed.JSONFeed(w)
+	default:
+		return fmt.Errorf("unknown export format: %q", format)
+	}
+}
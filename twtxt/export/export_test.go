@@ -0,0 +1,260 @@
+package export
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"duriny.envs.sh/twtr/twtxt"
+	"github.com/google/go-cmp/cmp"
+)
+
+// parse is a helper to create a twtxt file from its lines.
+func parse(t *testing.T, lines ...string) *twtxt.File {
+	t.Helper()
+
+	file, err := twtxt.Parse(strings.NewReader(strings.Join(lines, "\n")))
+	if err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	return file
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name string
+		nick string
+		file []string
+		want *Feed
+	}{
+		{
+			name: "Fields",
+			nick: "a",
+			file: []string{
+				"# nick = alice",
+				"# avatar = https://example.org/avatar.png",
+				"# description = Just testing",
+				"# link = My Website https://example.org",
+				"# link = https://example.org/blog",
+			},
+			want: &Feed{
+				Title:       "alice",
+				URL:         "https://example.org/alice.txt",
+				Author:      "alice",
+				Avatar:      "https://example.org/avatar.png",
+				Description: "Just testing",
+				Links: []Link{
+					{Text: "My Website", URL: "https://example.org"},
+					{Text: "https://example.org/blog", URL: "https://example.org/blog"},
+				},
+			},
+		},
+		{
+			name: "Nick",
+			nick: "alice",
+			want: &Feed{
+				Title:  "alice",
+				URL:    "https://example.org/alice.txt",
+				Author: "alice",
+			},
+		},
+		{
+			name: "Tweets",
+			file: []string{
+				"2016-02-04T13:30:00+01:00\tlunch?",
+				"2016-02-03T23:05:00+01:00\twelcome to twtxt!",
+			},
+			want: &Feed{
+				Title:   "https://example.org/alice.txt",
+				URL:     "https://example.org/alice.txt",
+				Updated: time.Date(2016, 2, 4, 12, 30, 0, 0, time.UTC),
+				Items: []Item{
+					{
+						Hash: "3w2uabq",
+						URL:  "https://example.org/alice.txt",
+						Time: time.Date(2016, 2, 4, 12, 30, 0, 0, time.UTC),
+						Text: "lunch?",
+					},
+					{
+						Hash: "jx2cp5q",
+						URL:  "https://example.org/alice.txt",
+						Time: time.Date(2016, 2, 3, 22, 5, 0, 0, time.UTC),
+						Text: "welcome to twtxt!",
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			have := New(test.nick, "https://example.org/alice.txt", parse(t, test.file...))
+
+			opt := cmp.Comparer(func(x, y time.Time) bool { return x.Equal(y) })
+			if !cmp.Equal(have, test.want, opt) {
+				t.Errorf("diff:\n%s", cmp.Diff(have, test.want, opt))
+			}
+		})
+	}
+}
+
+func TestItemTitle(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{
+			name: "Short",
+			text: "welcome to twtxt!",
+			want: "welcome to twtxt!",
+		},
+		{
+			name: "Lines",
+			text: "welcome to twtxt!\nthe decentralised microblog",
+			want: "welcome to twtxt!",
+		},
+		{
+			name: "Long",
+			text: strings.Repeat("twtxt ", 20),
+			want: strings.Repeat("twtxt ", 13) + "t…",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			if have, want := (Item{Text: test.text}).Title(), test.want; have != want {
+				t.Errorf("have %q, want %q", have, want)
+			}
+		})
+	}
+}
+
+func TestFeedWrite(t *testing.T) {
+	feed := New("alice", "https://example.org/alice.txt", parse(t,
+		"# avatar = https://example.org/avatar.png",
+		"# description = Just <testing>",
+		"# link = My Website https://example.org",
+		"2016-02-04T13:30:00+01:00\tlunch?\u2028@<bob https://example.org/bob.txt> & carol",
+	))
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{
+			format: "atom",
+			want: `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <id>https://example.org/alice.txt</id>
+  <title>alice</title>
+  <subtitle>Just &lt;testing&gt;</subtitle>
+  <updated>2016-02-04T13:30:00+01:00</updated>
+  <icon>https://example.org/avatar.png</icon>
+  <author>
+    <name>alice</name>
+    <uri>https://example.org/alice.txt</uri>
+  </author>
+  <link href="https://example.org/alice.txt" rel="alternate" type="text/plain"></link>
+  <link href="https://example.org" rel="related" title="My Website"></link>
+  <entry>
+    <id>https://example.org/alice.txt#v7oeisa</id>
+    <title>lunch?</title>
+    <updated>2016-02-04T13:30:00+01:00</updated>
+    <author>
+      <name>alice</name>
+      <uri>https://example.org/alice.txt</uri>
+    </author>
+    <content type="text">lunch?&#xA;@&lt;bob https://example.org/bob.txt&gt; &amp; carol</content>
+  </entry>
+</feed>
+`,
+		},
+		{
+			format: "rss",
+			want: `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:dc="http://purl.org/dc/elements/1.1/">
+  <channel>
+    <title>alice</title>
+    <link>https://example.org/alice.txt</link>
+    <description>Just &lt;testing&gt;</description>
+    <lastBuildDate>Thu, 04 Feb 2016 13:30:00 +0100</lastBuildDate>
+    <image>
+      <url>https://example.org/avatar.png</url>
+      <title>alice</title>
+      <link>https://example.org/alice.txt</link>
+    </image>
+    <item>
+      <title>lunch?</title>
+      <description>lunch?&#xA;@&lt;bob https://example.org/bob.txt&gt; &amp; carol</description>
+      <dc:creator>alice</dc:creator>
+      <pubDate>Thu, 04 Feb 2016 13:30:00 +0100</pubDate>
+      <guid isPermaLink="false">https://example.org/alice.txt#v7oeisa</guid>
+    </item>
+  </channel>
+</rss>
+`,
+		},
+		{
+			format: "jsonfeed",
+			want: `{
+  "version": "https://jsonfeed.org/version/1.1",
+  "title": "alice",
+  "home_page_url": "https://example.org",
+  "description": "Just <testing>",
+  "icon": "https://example.org/avatar.png",
+  "authors": [
+    {
+      "name": "alice",
+      "url": "https://example.org/alice.txt",
+      "avatar": "https://example.org/avatar.png"
+    }
+  ],
+  "items": [
+    {
+      "id": "https://example.org/alice.txt#v7oeisa",
+      "title": "lunch?",
+      "content_text": "lunch?\n@<bob https://example.org/bob.txt> & carol",
+      "date_published": "2016-02-04T13:30:00+01:00",
+      "authors": [
+        {
+          "name": "alice",
+          "url": "https://example.org/alice.txt"
+        }
+      ]
+    }
+  ]
+}
+`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.format, func(t *testing.T) {
+			var have strings.Builder
+
+			if err := feed.Write(&have, test.format); err != nil {
+				t.Fatalf("unexpected error: %q", err)
+			}
+
+			if have, want := have.String(), test.want; have != want {
+				t.Errorf("diff:\n%s", cmp.Diff(have, want))
+			}
+		})
+	}
+
+	t.Run("unknown", func(t *testing.T) {
+		var have strings.Builder
+
+		if err := feed.Write(&have, "opml"); err == nil {
+			t.Errorf("expected an error, have %q", have.String())
+		}
+	})
+}
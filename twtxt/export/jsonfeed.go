@@ -0,0 +1,69 @@
+package export
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// jsonFeed is a JSON Feed, see https://www.jsonfeed.org/version/1.1/.
+type jsonFeed struct {
+	Version     string         `json:"version"`
+	Title       string         `json:"title"`
+	HomePageURL string         `json:"home_page_url,omitempty"`
+	FeedURL     string         `json:"feed_url,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Icon        string         `json:"icon,omitempty"`
+	Authors     []jsonAuthor   `json:"authors,omitempty"`
+	Items       []jsonFeedItem `json:"items"`
+}
+
+type jsonAuthor struct {
+	Name   string `json:"name"`
+	URL    string `json:"url,omitempty"`
+	Avatar string `json:"avatar,omitempty"`
+}
+
+type jsonFeedItem struct {
+	ID            string       `json:"id"`
+	Title         string       `json:"title,omitempty"`
+	ContentText   string       `json:"content_text"`
+	DatePublished string       `json:"date_published"`
+	Authors       []jsonAuthor `json:"authors,omitempty"`
+}
+
+// JSONFeed writes the Feed as a JSON Feed.
+func (feed *Feed) JSONFeed(w io.Writer) error {
+	jf := jsonFeed{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       feed.Title,
+		Description: feed.Description,
+		Icon:        feed.Avatar,
+		Items:       make([]jsonFeedItem, 0, len(feed.Items)),
+	}
+
+	// the first link is the home page, e.g. "# link = Website https://..."
+	if len(feed.Links) > 0 {
+		jf.HomePageURL = feed.Links[0].URL
+	}
+
+	if feed.Author != "" {
+		jf.Authors = []jsonAuthor{{Name: feed.Author, URL: feed.URL, Avatar: feed.Avatar}}
+	}
+
+	for _, item := range feed.sorted() {
+		jf.Items = append(jf.Items, jsonFeedItem{
+			ID:            item.ID(),
+			Title:         item.Title(),
+			ContentText:   item.Text,
+			DatePublished: item.Time.Format(time.RFC3339),
+			Authors:       []jsonAuthor{{Name: item.Author, URL: item.URL}},
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(jf)
+}
@@ -0,0 +1,82 @@
+package export
+
+import (
+	"encoding/xml"
+	"io"
+	"time"
+)
+
+// rssFeed is the root element of an RSS 2.0 feed, the Dublin Core creator is
+// used for the nick of each item, as the RSS author has to be an email.
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	DC      string     `xml:"xmlns:dc,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Image         *rssImage `xml:"image,omitempty"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssImage struct {
+	URL   string `xml:"url"`
+	Title string `xml:"title"`
+	Link  string `xml:"link"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Description string  `xml:"description"`
+	Creator     string  `xml:"dc:creator,omitempty"`
+	PubDate     string  `xml:"pubDate"`
+	GUID        rssGUID `xml:"guid"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	ID          string `xml:",chardata"`
+}
+
+// RSS writes the Feed as an RSS 2.0 feed.
+func (feed *Feed) RSS(w io.Writer) error {
+	rss := rssFeed{
+		Version: "2.0",
+		DC:      "http://purl.org/dc/elements/1.1/",
+		Channel: rssChannel{
+			Title:       feed.Title,
+			Link:        feed.URL,
+			Description: feed.Description,
+		},
+	}
+
+	// the channel needs a description, even an empty one
+	if rss.Channel.Description == "" {
+		rss.Channel.Description = "twtxt feed of " + feed.Title
+	}
+
+	if !feed.Updated.IsZero() {
+		rss.Channel.LastBuildDate = feed.Updated.Format(time.RFC1123Z)
+	}
+
+	if feed.Avatar != "" {
+		rss.Channel.Image = &rssImage{URL: feed.Avatar, Title: feed.Title, Link: feed.URL}
+	}
+
+	for _, item := range feed.sorted() {
+		rss.Channel.Items = append(rss.Channel.Items, rssItem{
+			Title:       item.Title(),
+			Description: item.Text,
+			Creator:     item.Author,
+			PubDate:     item.Time.Format(time.RFC1123Z),
+			GUID:        rssGUID{ID: item.ID()},
+		})
+	}
+
+	return writeXML(w, rss)
+}
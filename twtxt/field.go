@@ -6,6 +6,11 @@ type Field struct {
 	key, val string
 }
 
+// NewField creates a new metadata Field with the given name and value.
+func NewField(name, value string) *Field {
+	return &Field{key: name, val: value}
+}
+
 // Name returns the name (or key) of the field.
 func (field *Field) Name() string {
 	return field.key
@@ -64,3 +64,11 @@ func TestField(t *testing.T) {
 		})
 	}
 }
+
+func TestNewField(t *testing.T) {
+	field := NewField("nick", "alice")
+
+	if have, want := field.String(), "# nick = alice"; have != want {
+		t.Errorf("have %q, want %q", have, want)
+	}
+}
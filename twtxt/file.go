@@ -1,9 +1,8 @@
 package twtxt
 
 import (
-	"bufio"
+	"bytes"
 	"io"
-	"strings"
 	"time"
 )
 
@@ -24,127 +23,184 @@ type File struct {
 //
 // Parse also supports community metadata fields, including the Yarn.Social
 // metadata extensions: https://dev.twtxt.net/doc/metadataextension.html
+//
+// Escaped newlines, tabs and backslashes, as well as the Yarn.Social line
+// separator, are decoded when parsing, see Tweet.Text for the decoded message.
+//
+// Parse holds the entire feed in memory, and parses lines of any length, use a
+// Decoder to read large feeds one Field or Tweet at a time, or to limit the
+// length of the lines with Decoder.Decode.
 func Parse(source io.Reader) (*File, error) {
-	file := &File{
-		make(Fields, 0),
-		make(Tweets, 0),
-	}
+	return NewDecoder(source).Decode()
+}
 
-	// if the source is nil, then there aren't any Tweets
-	if source == nil {
-		return file, nil
+// parseField is a helper to Decoder.Next(), it reads a single comment line
+// and returns a metadata Field if any is found, and nil otherwise.
+func parseField(line []byte) *Field {
+	// ignore comments without an equal sign
+	i := bytes.IndexByte(line, '=')
+	if i < 0 {
+		return nil
 	}
 
-	// create a scanner to read the source
-	scanner := bufio.NewScanner(source)
+	// split the line by the equal sign delimiter, trimming whitespace padding
+	key := bytes.TrimSpace(line[1:i])
+	val := bytes.TrimSpace(line[i+1:])
 
-	// parse each line into Tweet
-	var lineNumber uint64
-	for scanner.Scan() {
-		// increment line count
-		lineNumber++
+	// there has to be a key
+	if len(key) == 0 {
+		return nil
+	}
 
-		// read next line
-		line := scanner.Text()
+	// there has to be a value
+	if len(val) == 0 {
+		return nil
+	}
 
-		// try to parse the line as a metadata field
-		if field := parseField(line); field != nil {
-			file.Fields = append(file.Fields, field)
-		}
+	return &Field{
+		key: string(key),
+		val: string(val),
+	}
+}
 
-		// try to parse the line as a tweet
-		tweet, perr := parseTweet(line)
+// parseTweet is a helper to Decoder.Next(), it reads a single line that is not
+// a comment and returns a Tweet if the line can be parsed as one, and returns
+// a ParseError if the line does not contain a valid Tweet.
+func (dec *Decoder) parseTweet(line []byte) (*Tweet, *ParseError) {
+	// there has to be a tab delimiter
+	i := bytes.IndexByte(line, '\t')
+	if i < 0 {
+		return nil, &ParseError{msg: "missing tab delimiter"}
+	}
 
-		// catch any parse errors
-		if perr != nil {
-			perr.line = lineNumber
+	// there has to be a timestamp
+	if i == 0 {
+		return nil, &ParseError{msg: "missing timestamp"}
+	}
 
-			return nil, perr
-		}
+	// parse the timestamp
+	t, err := dec.parseTimestamp(line[:i])
+	if err != nil {
+		return nil, &ParseError{inner: err}
+	}
 
-		// otherwise store the parsed tweet
-		if tweet != nil {
-			file.Tweets = append(file.Tweets, tweet)
-		}
+	raw := string(line[i+1:])
+
+	tweet := dec.newTweet()
+	tweet.time = t
+	tweet.post = decodeText(raw)
 
+	// keep the post as written if it isn't written the way twtr would, so that
+	// the twt hash is of the post as written, a post without any escapes or
+	// line separators decodes unchanged and is always written the same way
+	if (tweet.post != raw || bytes.IndexByte(line[i+1:], '\\') >= 0) && encodeText(tweet.post) != raw {
+		tweet.raw = raw
 	}
 
-	// bail early if there is a reading error
-	if err := scanner.Err(); err != nil {
-		return nil, err
+	return tweet, nil
+}
+
+// parseTimestamp is a helper to parseTweet(), it parses an RFC3339 timestamp.
+// The common forms of timestamp, with whole seconds and either a "Z" or a
+// numeric offset, are parsed without any allocations, anything else is left to
+// time.Parse.
+func (dec *Decoder) parseTimestamp(b []byte) (time.Time, error) {
+	if t, ok := dec.parseTimestampFast(b); ok {
+		return t, nil
 	}
 
-	return file, nil
+	return time.Parse(time.RFC3339, string(b))
 }
 
-// parseField is a helper to parseLine(), it reads a single line and returns a
-// metadata Field if any is found, and nil otherwise.
-func parseField(line string) *Field {
-	// ignore non-comments
-	if !strings.HasPrefix(line, "#") {
-		return nil
+// parseTimestampFast is a helper to parseTimestamp(), it reports false if the
+// timestamp is not in one of the common forms, or is not a valid time.
+//
+//     <yyyy>-<mm>-<dd>T<HH>:<MM>:<SS>Z
+//     <yyyy>-<mm>-<dd>T<HH>:<MM>:<SS><+/-><XX>:<ZZ>
+func (dec *Decoder) parseTimestampFast(b []byte) (time.Time, bool) {
+	if len(b) != 20 && len(b) != 25 {
+		return time.Time{}, false
 	}
 
-	// ignore comments without an equal sign
-	if !strings.Contains(line, "=") {
-		return nil
+	if b[4] != '-' || b[7] != '-' || b[10] != 'T' || b[13] != ':' || b[16] != ':' {
+		return time.Time{}, false
 	}
 
-	// split the line by the tab delimiter
-	parts := strings.SplitN(line[1:], "=", 2)
+	year, ok1 := atoi(b[0:4])
+	month, ok2 := atoi(b[5:7])
+	day, ok3 := atoi(b[8:10])
+	hour, ok4 := atoi(b[11:13])
+	min, ok5 := atoi(b[14:16])
+	sec, ok6 := atoi(b[17:19])
 
-	// trim whitespace padding from the parts
-	for i, part := range parts {
-		parts[i] = strings.TrimSpace(part)
+	if !(ok1 && ok2 && ok3 && ok4 && ok5 && ok6) {
+		return time.Time{}, false
 	}
 
-	// there has to be a key
-	if len(parts) < 1 || parts[0] == "" {
-		return nil
+	// leave the error messages of out of range values to time.Parse
+	if month < 1 || month > 12 || day < 1 || day > daysIn(time.Month(month), year) {
+		return time.Time{}, false
 	}
 
-	// there has to be an equal sign delimiter and a value
-	if len(parts) < 2 || parts[1] == "" {
-		return nil
+	if hour > 23 || min > 59 || sec > 59 {
+		return time.Time{}, false
 	}
 
-	return &Field{
-		key: parts[0],
-		val: parts[1],
+	// timestamps in UTC
+	if len(b) == 20 {
+		if b[19] != 'Z' {
+			return time.Time{}, false
+		}
+
+		return time.Date(year, time.Month(month), day, hour, min, sec, 0, time.UTC), true
 	}
-}
 
-// parseTweet is a helper to parseLine(), it reads a single line and returns a
-// Tweet if the line can be parsed as one, returns nil for both values if the
-// line is a comment of any kind, and returns a ParseError if the line is not a
-// comment and does not contain a valid Tweet.
-func parseTweet(line string) (*Tweet, *ParseError) {
-	// ignore comments
-	if strings.HasPrefix(line, "#") {
-		return nil, nil
+	// timestamps with an offset
+	if (b[19] != '+' && b[19] != '-') || b[22] != ':' {
+		return time.Time{}, false
 	}
 
-	// there has to be a tab delimiter
-	if !strings.Contains(line, "\t") {
-		return nil, &ParseError{msg: "missing tab delimiter"}
+	zoneHour, ok1 := atoi(b[20:22])
+	zoneMin, ok2 := atoi(b[23:25])
+
+	if !ok1 || !ok2 || zoneHour > 23 || zoneMin > 59 {
+		return time.Time{}, false
 	}
 
-	// split the line by the tab delimiter
-	parts := strings.SplitN(line, "\t", 2)
+	offset := zoneHour*60*60 + zoneMin*60
+	if b[19] == '-' {
+		offset = -offset
+	}
 
-	// there has to be a timestamp
-	if len(parts) < 1 || parts[0] == "" {
-		return nil, &ParseError{msg: "missing timestamp"}
+	t := time.Date(year, time.Month(month), day, hour, min, sec, 0, time.UTC)
+	t = t.Add(-time.Duration(offset) * time.Second)
+
+	// like time.Parse, use the local time zone if it has the same offset
+	if _, local := t.In(time.Local).Zone(); local == offset {
+		return t.In(time.Local), true
 	}
 
-	// parse the timestamp
-	t, err := time.Parse(time.RFC3339, parts[0])
-	if err != nil {
-		return nil, &ParseError{inner: err}
+	return t.In(dec.zone(offset)), true
+}
+
+// atoi is a helper to parseTimestampFast(), it parses a small unsigned decimal
+// number, reporting false if there are any non-digits.
+func atoi(b []byte) (int, bool) {
+	var n int
+
+	for _, c := range b {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+
+		n = n*10 + int(c-'0')
 	}
 
-	return &Tweet{
-		time: t,
-		post: parts[1],
-	}, nil
+	return n, true
+}
+
+// daysIn is a helper to parseTimestampFast(), it returns the number of days in
+// the month of the given year.
+func daysIn(month time.Month, year int) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
 }
@@ -1,6 +1,7 @@
 package twtxt
 
 import (
+	"bytes"
 	"errors"
 	"io"
 	"strings"
@@ -40,7 +41,19 @@ func TestParse(t *testing.T) {
 			tweets: Tweets{
 				&Tweet{
 					time: time.Date(2022, 1, 19, 14, 14, 0, 0, loc(+13)),
-					post: "This post contains newlines\\n\\n\\n",
+					post: "This post contains newlines\n\n\n",
+				},
+			},
+		},
+		{
+			name:   "LineSeparator",
+			source: strings.NewReader("2022-01-19T14:14:00+13:00\tThis post contains\u2028line separators\u2028"),
+			fields: Fields{},
+			tweets: Tweets{
+				&Tweet{
+					time: time.Date(2022, 1, 19, 14, 14, 0, 0, loc(+13)),
+					post: "This post contains\nline separators\n",
+					raw:  "This post contains\u2028line separators\u2028",
 				},
 			},
 		},
@@ -58,7 +71,7 @@ func TestParse(t *testing.T) {
 			tweets: Tweets{
 				&Tweet{
 					time: time.Date(2022, 1, 19, 14, 11, 0, 0, loc(+13)),
-					post: "This post contains tabs\\t\\t\\t",
+					post: "This post contains tabs\t\t\t",
 				},
 				&Tweet{
 					time: time.Date(2016, 2, 3, 23, 5, 0, 0, loc(+1)),
@@ -66,7 +79,7 @@ func TestParse(t *testing.T) {
 				},
 				&Tweet{
 					time: time.Date(2022, 1, 19, 14, 14, 0, 0, loc(+13)),
-					post: "This post contains newlines\\n\\n\\n",
+					post: "This post contains newlines\n\n\n",
 				},
 				&Tweet{
 					time: time.Date(2016, 2, 1, 11, 0, 0, 0, loc(+1)),
@@ -167,7 +180,7 @@ func TestParse(t *testing.T) {
 			tweets: Tweets{
 				&Tweet{
 					time: time.Date(2022, 1, 19, 14, 11, 0, 0, loc(+13)),
-					post: "This post contains tabs\\t\\t\\t",
+					post: "This post contains tabs\t\t\t",
 				},
 				&Tweet{
 					time: time.Date(2016, 2, 3, 23, 5, 0, 0, loc(+1)),
@@ -175,7 +188,7 @@ func TestParse(t *testing.T) {
 				},
 				&Tweet{
 					time: time.Date(2022, 1, 19, 14, 14, 0, 0, loc(+13)),
-					post: "This post contains newlines\\n\\n\\n",
+					post: "This post contains newlines\n\n\n",
 				},
 				&Tweet{
 					time: time.Date(2016, 2, 1, 11, 0, 0, 0, loc(+1)),
@@ -300,3 +313,72 @@ func TestParse(t *testing.T) {
 		})
 	}
 }
+
+func TestParseRoundTrip(t *testing.T) {
+	lines := []string{
+		"2022-01-19T14:11:00+13:00\tThis post contains tabs\\t\\t\\t",
+		"2022-01-19T14:14:00+13:00\tThis post contains newlines\\n\\n\\n",
+		"2022-01-19T14:15:00+13:00\tfunc main() {\\n\\tfmt.Println(\"hello\")\\n}",
+		"2016-02-04T13:30:00+01:00\tYou can really go crazy here! ┐(ﾟ∀ﾟ)┌",
+		"2016-02-04T13:30:00+01:00\tC:\\path\\x",
+		"2016-02-04T13:31:00+01:00\ta literal \\\\n next to a lone \\",
+	}
+
+	file, err := Parse(strings.NewReader(strings.Join(lines, "\n")))
+	if err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	if have, want := len(file.Tweets), len(lines); have != want {
+		t.Fatalf("have %d tweets, want %d", have, want)
+	}
+
+	for i, tweet := range file.Tweets {
+		if have, want := tweet.String(), lines[i]; have != want {
+			t.Errorf("\nhave: %q\nwant: %q", have, want)
+		}
+	}
+}
+
+// benchmarkFeed is a helper to generate a feed of roughly the given size in
+// bytes, containing a mix of comments, fields, and tweets.
+func benchmarkFeed(size int) []byte {
+	var feed strings.Builder
+
+	feed.WriteString("# nick = buckket\n")
+	feed.WriteString("# url = https://example.org/twtxt.txt\n")
+	feed.WriteString("# this is just a comment\n")
+
+	start := time.Date(2016, 2, 3, 23, 5, 0, 0, loc(+1))
+
+	for i := 0; feed.Len() < size; i++ {
+		twt := &Tweet{
+			time: start.Add(time.Duration(i) * time.Minute),
+			post: "@<example http://example.org/twtxt.txt> welcome to twtxt! ┐(ﾟ∀ﾟ)┌",
+		}
+
+		// every so often include an escaped newline
+		if i%10 == 0 {
+			twt.post += "\nThis is just another example."
+		}
+
+		feed.WriteString(twt.String())
+		feed.WriteString("\n")
+	}
+
+	return []byte(feed.String())
+}
+
+func BenchmarkParse(b *testing.B) {
+	feed := benchmarkFeed(10 * 1024 * 1024)
+
+	b.SetBytes(int64(len(feed)))
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := Parse(bytes.NewReader(feed)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package twtxt
+
+import "strings"
+
+// Mention is a reference to another feed in the post of a Tweet, written as
+// "@<nick url>", or as "@<url>" when the nick is not known.
+type Mention struct {
+	nick, url string
+}
+
+// NewMention creates a Mention of the feed at url, the nick may be empty.
+func NewMention(nick, url string) *Mention {
+	return &Mention{nick: nick, url: url}
+}
+
+// Nick returns the nick of the mentioned feed, or an empty string if the
+// Mention only has a url.
+func (mention *Mention) Nick() string {
+	return mention.nick
+}
+
+// URL returns the url of the mentioned feed.
+func (mention *Mention) URL() string {
+	return mention.url
+}
+
+// Mentions reports whether the Mention refers to the feed at url, ignoring the
+// case of the url and any trailing slash.
+func (mention *Mention) Mentions(url string) bool {
+	return strings.EqualFold(
+		strings.TrimSuffix(mention.url, "/"),
+		strings.TrimSuffix(url, "/"),
+	)
+}
+
+// String returns the Mention formatted as it is written in a post.
+func (mention *Mention) String() string {
+	if mention.nick == "" {
+		return "@<" + mention.url + ">"
+	}
+
+	return "@<" + mention.nick + " " + mention.url + ">"
+}
@@ -0,0 +1,49 @@
+package twtxt
+
+import "testing"
+
+func TestMention(t *testing.T) {
+	tests := []struct {
+		name     string
+		mention  *Mention
+		str      string
+		mentions map[string]bool
+	}{
+		{
+			name:    "NickAndURL",
+			mention: NewMention("bob", "https://example.org/bob.txt"),
+			str:     "@<bob https://example.org/bob.txt>",
+			mentions: map[string]bool{
+				"https://example.org/bob.txt":   true,
+				"HTTPS://EXAMPLE.ORG/bob.txt":   true,
+				"https://example.org/alice.txt": false,
+			},
+		},
+		{
+			name:    "URLOnly",
+			mention: NewMention("", "https://example.org/"),
+			str:     "@<https://example.org/>",
+			mentions: map[string]bool{
+				"https://example.org":           true,
+				"https://example.org/":          true,
+				"https://example.org/twtxt.txt": false,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			if have, want := test.mention.String(), test.str; have != want {
+				t.Errorf("have %q, want %q", have, want)
+			}
+
+			for url, want := range test.mentions {
+				if have := test.mention.Mentions(url); have != want {
+					t.Errorf("Mentions(%q) = %t, want %t", url, have, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package server
+
+import (
+	"errors"
+	"io/fs"
+	"net/http"
+	"os"
+
+	"duriny.envs.sh/twtr/twtxt"
+	"duriny.envs.sh/twtr/twtxt/export"
+)
+
+// Export is an http.Handler that serves a twtxt.txt file as an Atom, RSS, or
+// JSON Feed, e.g. mounted on "/atom.xml", so that the feed can be read in any
+// feed reader.
+type Export struct {
+	// Path is the path of the twtxt.txt file to export.
+	Path string
+
+	// Nick and URL are the nick and url of the feed, the nick is overridden by
+	// the nick metadata field of the file.
+	Nick string
+	URL  string
+
+	// Format is one of export.Formats.
+	Format string
+}
+
+// NewExport creates an Export that serves the twtxt.txt file at path, hosted
+// at url, in the given format.
+func NewExport(path, nick, url, format string) *Export {
+	return &Export{Path: path, Nick: nick, URL: url, Format: format}
+}
+
+// ServeHTTP serves the exported feed, only GET and HEAD requests are allowed.
+func (h *Export) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	file, err := os.Open(h.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		http.NotFound(w, r)
+		return
+	}
+
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	defer file.Close()
+
+	twtfile, err := twtxt.Parse(file)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	contentType, ok := export.ContentTypes[h.Format]
+	if !ok {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+
+	if r.Method == http.MethodHead {
+		return
+	}
+
+	export.New(h.Nick, h.URL, twtfile).Write(w, h.Format)
+}
@@ -0,0 +1,164 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Handler is an http.Handler that serves a twtxt.txt file, it can be mounted on
+// any path, e.g. "/twtxt.txt". The file is read for each request, so it can be
+// changed while the Handler is serving it.
+//
+// Conditional requests, with the ETag and Last-Modified headers, as well as
+// Range requests are supported, so clients only need to download the Tweets
+// that they have not seen yet.
+type Handler struct {
+	// Path is the path of the twtxt.txt file to serve.
+	Path string
+
+	// Log is written an access log of each request in the combined log format,
+	// which includes the User-Agents that followers announce themselves with.
+	// A nil Log means requests are not logged.
+	Log io.Writer
+
+	mu sync.Mutex // guards writes to Log
+}
+
+// New creates a Handler that serves the twtxt.txt file at path.
+func New(path string) *Handler {
+	return &Handler{Path: path}
+}
+
+// contentType is the content type of a twtxt.txt file.
+const contentType = "text/plain; charset=utf-8"
+
+// ServeHTTP serves the twtxt.txt file, only GET and HEAD requests are allowed.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rec := &recorder{ResponseWriter: w, status: http.StatusOK}
+	defer h.log(rec, r, time.Now())
+
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		rec.Header().Set("Allow", "GET, HEAD")
+		http.Error(rec, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	file, err := os.Open(h.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		http.NotFound(rec, r)
+		return
+	}
+
+	if err != nil {
+		http.Error(rec, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil || info.IsDir() {
+		http.Error(rec, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	rec.Header().Set("Content-Type", contentType)
+	rec.Header().Set("ETag", etag(info))
+
+	// ServeContent handles the conditional and Range requests
+	http.ServeContent(rec, r, info.Name(), info.ModTime(), file)
+}
+
+// etag is a helper to ServeHTTP(), it returns an ETag that changes whenever the
+// file is modified, without having to read the file.
+func etag(info fs.FileInfo) string {
+	return fmt.Sprintf(`"%x-%x"`, info.ModTime().UnixNano(), info.Size())
+}
+
+// log is a helper to ServeHTTP(), it writes the request to the access log.
+//
+//     host - - [time] "request" status bytes "referer" "user-agent"
+func (h *Handler) log(rec *recorder, r *http.Request, t time.Time) {
+	if h.Log == nil {
+		return
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	fmt.Fprintf(h.Log, "%s - - [%s] %s %d %d %s %s\n",
+		host,
+		t.Format("02/Jan/2006:15:04:05 -0700"),
+		quote(r.Method+" "+r.RequestURI+" "+r.Proto),
+		rec.status,
+		rec.size,
+		quote(orDash(r.Referer())),
+		quote(orDash(r.UserAgent())),
+	)
+}
+
+// quote is a helper to log(), it quotes a field of the access log, escaping
+// any quotes and backslashes.
+func quote(s string) string {
+	b := []byte{'"'}
+
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '"' || c == '\\':
+			b = append(b, '\\', c)
+		case c < ' ' || c == 0x7f:
+			b = append(b, `\x`...)
+			b = strconv.AppendUint(b, uint64(c)>>4, 16)
+			b = strconv.AppendUint(b, uint64(c)&0xf, 16)
+		default:
+			b = append(b, c)
+		}
+	}
+
+	return string(append(b, '"'))
+}
+
+// orDash is a helper to log(), it returns "-" for a missing field.
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+
+	return s
+}
+
+// recorder wraps an http.ResponseWriter to record the status and size of the
+// response for the access log.
+type recorder struct {
+	http.ResponseWriter
+	status int
+	size   int
+}
+
+// WriteHeader records the status of the response.
+func (rec *recorder) WriteHeader(status int) {
+	rec.status = status
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+// Write records the size of the response.
+func (rec *recorder) Write(b []byte) (int, error) {
+	n, err := rec.ResponseWriter.Write(b)
+	rec.size += n
+
+	return n, err
+}
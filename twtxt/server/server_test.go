@@ -0,0 +1,204 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandler(t *testing.T) {
+	feed := "# nick = alice\n2016-02-04T13:30:00+01:00\tYou can really go crazy here! ┐(ﾟ∀ﾟ)┌\n"
+	path := filepath.Join(t.TempDir(), "twtxt.txt")
+
+	if err := os.WriteFile(path, []byte(feed), 0o644); err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	modified := time.Date(2016, 2, 4, 13, 30, 0, 0, time.UTC)
+	if err := os.Chtimes(path, modified, modified); err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	var log strings.Builder
+
+	handler := New(path)
+	handler.Log = &log
+
+	// serve is a helper to make a request to the handler.
+	serve := func(method string, header map[string]string) *http.Response {
+		req := httptest.NewRequest(method, "/twtxt.txt", nil)
+		for key, val := range header {
+			req.Header.Set(key, val)
+		}
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		return rec.Result()
+	}
+
+	resp := serve(http.MethodGet, map[string]string{
+		"User-Agent": "twtxt/1.2.3 (+https://example.org/bob.txt; @bob)",
+	})
+
+	body, _ := io.ReadAll(resp.Body)
+
+	if have, want := resp.StatusCode, http.StatusOK; have != want {
+		t.Fatalf("have status %d, want %d", have, want)
+	}
+
+	if have, want := string(body), feed; have != want {
+		t.Errorf("have body %q, want %q", have, want)
+	}
+
+	if have, want := resp.Header.Get("Content-Type"), "text/plain; charset=utf-8"; have != want {
+		t.Errorf("have Content-Type %q, want %q", have, want)
+	}
+
+	if have, want := resp.Header.Get("Last-Modified"), "Thu, 04 Feb 2016 13:30:00 GMT"; have != want {
+		t.Errorf("have Last-Modified %q, want %q", have, want)
+	}
+
+	etag := resp.Header.Get("ETag")
+	if etag == "" {
+		t.Fatal("missing ETag")
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		header map[string]string
+		status int
+		body   string
+	}{
+		{
+			name:   "IfNoneMatch",
+			method: http.MethodGet,
+			header: map[string]string{"If-None-Match": etag},
+			status: http.StatusNotModified,
+		},
+		{
+			name:   "IfModifiedSince",
+			method: http.MethodGet,
+			header: map[string]string{"If-Modified-Since": "Thu, 04 Feb 2016 13:30:00 GMT"},
+			status: http.StatusNotModified,
+		},
+		{
+			name:   "Range",
+			method: http.MethodGet,
+			header: map[string]string{"Range": "bytes=15-"},
+			status: http.StatusPartialContent,
+			body:   feed[15:],
+		},
+		{
+			name:   "Head",
+			method: http.MethodHead,
+			status: http.StatusOK,
+		},
+		{
+			name:   "Post",
+			method: http.MethodPost,
+			status: http.StatusMethodNotAllowed,
+			body:   "Method Not Allowed\n",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			resp := serve(test.method, test.header)
+			body, _ := io.ReadAll(resp.Body)
+
+			if have, want := resp.StatusCode, test.status; have != want {
+				t.Errorf("have status %d, want %d", have, want)
+			}
+
+			if have, want := string(body), test.body; have != want {
+				t.Errorf("have body %q, want %q", have, want)
+			}
+		})
+	}
+
+	lines := strings.Split(strings.TrimSuffix(log.String(), "\n"), "\n")
+	if have, want := len(lines), len(tests)+1; have != want {
+		t.Fatalf("have %d lines of access log, want %d", have, want)
+	}
+
+	if want := fmt.Sprintf(`"GET /twtxt.txt HTTP/1.1" 200 %d `, len(feed)); !strings.Contains(lines[0], want) {
+		t.Errorf("have log line %q, want it to contain %q", lines[0], want)
+	}
+
+	if want := `"-" "twtxt/1.2.3 (+https://example.org/bob.txt; @bob)"`; !strings.HasSuffix(lines[0], want) {
+		t.Errorf("have log line %q, want it to end with %q", lines[0], want)
+	}
+
+	// a missing file is not found
+	if err := os.Remove(path); err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	if have, want := serve(http.MethodGet, nil).StatusCode, http.StatusNotFound; have != want {
+		t.Errorf("have status %d, want %d", have, want)
+	}
+}
+
+func TestExport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "twtxt.txt")
+
+	if err := os.WriteFile(path, []byte("2016-02-04T13:30:00+01:00\tlunch?\n"), 0o644); err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	tests := []struct {
+		format      string
+		contentType string
+		contains    string
+	}{
+		{
+			format:      "atom",
+			contentType: "application/atom+xml; charset=utf-8",
+			contains:    "<id>https://example.org/twtxt.txt#",
+		},
+		{
+			format:      "rss",
+			contentType: "application/rss+xml; charset=utf-8",
+			contains:    "<dc:creator>alice</dc:creator>",
+		},
+		{
+			format:      "jsonfeed",
+			contentType: "application/feed+json; charset=utf-8",
+			contains:    `"content_text": "lunch?"`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.format, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			NewExport(path, "alice", "https://example.org/twtxt.txt", test.format).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/atom.xml", nil))
+
+			resp := rec.Result()
+			body, _ := io.ReadAll(resp.Body)
+
+			if have, want := resp.StatusCode, http.StatusOK; have != want {
+				t.Fatalf("have status %d, want %d", have, want)
+			}
+
+			if have, want := resp.Header.Get("Content-Type"), test.contentType; have != want {
+				t.Errorf("have content type %q, want %q", have, want)
+			}
+
+			if !strings.Contains(string(body), test.contains) {
+				t.Errorf("body %q does not contain %q", body, test.contains)
+			}
+		})
+	}
+}
@@ -1,8 +1,12 @@
 package twtxt
 
 import (
+	"encoding/base32"
+	"regexp"
 	"strings"
 	"time"
+
+	"golang.org/x/crypto/blake2b"
 )
 
 // Tweet represents a single twtxt post, containing the timestamp and the main
@@ -10,6 +14,7 @@ import (
 type Tweet struct {
 	time time.Time
 	post string
+	raw  string // the post as written in the feed, if it differs from post
 }
 
 // NewTweet creates a new Tweet instance with the given post message and the
@@ -23,6 +28,12 @@ func NewTweet(post string) *Tweet {
 	return twt
 }
 
+// NewTweetAt creates a new Tweet instance with the given post message and the
+// time that it was posted, e.g. when converting posts from other sources.
+func NewTweetAt(post string, t time.Time) *Tweet {
+	return &Tweet{time: t, post: post}
+}
+
 // Time gets the time that the Tweet was posted.
 func (twt *Tweet) Time() time.Time {
 	if twt.time.IsZero() {
@@ -32,11 +43,60 @@ func (twt *Tweet) Time() time.Time {
 	return twt.time
 }
 
-// Post gets the posted message of the Tweet.
-func (twt *Tweet) Post() string {
+// Text gets the decoded message of the Tweet, any escaped newlines or tabs in
+// the twtxt.txt file are returned as real newline and tab characters.
+func (twt *Tweet) Text() string {
 	return twt.post
 }
 
+// Post gets the posted message of the Tweet, it is the same as Text.
+func (twt *Tweet) Post() string {
+	return twt.Text()
+}
+
+// Hash returns the twt hash of the Tweet, as posted in the feed at the given
+// url. Yarn.Social clients use the twt hash to refer to a Tweet, e.g. in the
+// subject of a reply.
+//
+// See https://dev.twtxt.net/doc/twthashextension.html for more information.
+func (twt *Tweet) Hash(url string) string {
+	post := twt.raw
+	if post == "" {
+		post = encodeText(twt.Text())
+	}
+
+	payload := url + "\n" + twt.Time().Format(time.RFC3339) + "\n" + post
+	sum := blake2b.Sum256([]byte(payload))
+
+	hash := strings.ToLower(hashEncoding.EncodeToString(sum[:]))
+
+	return hash[len(hash)-hashLength:]
+}
+
+// Subject returns the twt hash in the subject of the Tweet, e.g. a reply that
+// starts with "(#abcdefg)" or "(#<abcdefg https://example.org/twt/abcdefg>)"
+// has the subject "abcdefg". Returns an empty string if there is no subject.
+func (twt *Tweet) Subject() string {
+	match := subject.FindStringSubmatch(twt.Text())
+	if match == nil {
+		return ""
+	}
+
+	return match[1]
+}
+
+// Mentions returns the Mentions in the post of the Tweet, in the order they
+// were written, in both the "@<nick url>" and "@<url>" forms.
+func (twt *Tweet) Mentions() []*Mention {
+	var mentions []*Mention
+
+	for _, match := range mention.FindAllStringSubmatch(twt.Text(), -1) {
+		mentions = append(mentions, NewMention(match[1], match[2]))
+	}
+
+	return mentions
+}
+
 // Before determines if one Tweet was posted before the other.
 func (twt *Tweet) Before(other *Tweet) bool {
 	return twt.Time().Before(other.Time())
@@ -48,19 +108,80 @@ func (twt *Tweet) After(other *Tweet) bool {
 }
 
 // String formats the Tweet as an entry into a twtxt.txt file, returns the
-// timestamp followed by a tab character and the post message. Any tabs or new
-// line characters are escaped to prevent invalid formatting of the twtxt.txt
-// file.
+// timestamp followed by a tab character and the post message. Any tabs, new
+// line characters, or backslashes are escaped to prevent invalid formatting of
+// the twtxt.txt file.
 //
 //     <yyyy>-<mm>-<dd>T<HH>:<MM>:<SS><+/-><XX>:<ZZ>\t<POST>
 //
 // See the format specification for more details on the file format:
 // https://twtxt.readthedocs.io/en/latest/user/twtxtfile.html
 func (twt *Tweet) String() string {
-	r := strings.NewReplacer(
-		"\n", "\\n",
-		"\t", "\\t",
+	return twt.Time().Format(time.RFC3339) + "\t" + encodeText(twt.Text())
+}
+
+const (
+	// hashLength is the number of characters in a twt hash.
+	hashLength = 7
+)
+
+var (
+	// hashEncoding is the encoding of the digest of a twt hash.
+	hashEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+	// subject matches the subject at the start of a post.
+	subject = regexp.MustCompile(`^\s*\(#<?([a-z0-9]+)(?: [^)>]*)?>?\)`)
+
+	// mention matches a mention of a feed, with an optional nick.
+	mention = regexp.MustCompile(`@<(?:([^ >]+) )?([^ >]+)>`)
+
+	// decoder reverses encoder, it also accepts the Yarn.Social line separator
+	// (U+2028) as a newline. The escapes are replaced in a single pass, so an
+	// escaped backslash followed by an "n" is not read as a newline.
+	decoder = strings.NewReplacer(
+		"\\\\", "\\",
+		"\\n", "\n",
+		"\\t", "\t",
+		"\u2028", "\n",
 	)
+)
+
+// encodeText escapes the newlines and tabs of a post so it fits on a single
+// line of a twtxt.txt file. A backslash is only escaped where it would be read
+// back as part of an escape, e.g. in a literal "\n", as other clients show an
+// escaped backslash as it is written.
+func encodeText(text string) string {
+	// most posts don't have anything to encode
+	if !strings.ContainsAny(text, "\\\n\t") {
+		return text
+	}
+
+	var b strings.Builder
+	b.Grow(len(text))
+
+	for i := 0; i < len(text); i++ {
+		switch c := text[i]; {
+		case c == '\n':
+			b.WriteString("\\n")
+		case c == '\t':
+			b.WriteString("\\t")
+		case c == '\\' && i+1 < len(text) && strings.IndexByte("nt\\\n\t", text[i+1]) >= 0:
+			b.WriteString("\\\\")
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return b.String()
+}
+
+// decodeText reverses encodeText, turning a post as written in a twtxt.txt
+// file back into its text.
+func decodeText(post string) string {
+	// most posts don't have anything to decode
+	if strings.IndexByte(post, '\\') < 0 && !strings.Contains(post, "\u2028") {
+		return post
+	}
 
-	return twt.Time().Format(time.RFC3339 + "\t" + r.Replace(twt.Post()))
+	return decoder.Replace(post)
 }
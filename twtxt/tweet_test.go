@@ -2,8 +2,11 @@ package twtxt
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/google/go-cmp/cmp"
 )
 
 // loc is a helper to create arbitrary time.Locations.
@@ -30,6 +33,23 @@ func TestNewTweet(t *testing.T) {
 	}
 }
 
+func TestNewTweetAt(t *testing.T) {
+	posted := time.Date(2016, 2, 4, 13, 30, 0, 0, loc(1))
+	tweet := NewTweetAt("You can really go crazy here! ┐(ﾟ∀ﾟ)┌", posted)
+
+	if have, want := tweet.Text(), "You can really go crazy here! ┐(ﾟ∀ﾟ)┌"; have != want {
+		t.Errorf("have %q, want %q", have, want)
+	}
+
+	if have, want := tweet.Time(), posted; !have.Equal(want) {
+		t.Errorf("have %s, want %s", have, want)
+	}
+
+	if have, want := tweet.String(), "2016-02-04T13:30:00+01:00\tYou can really go crazy here! ┐(ﾟ∀ﾟ)┌"; have != want {
+		t.Errorf("have %q, want %q", have, want)
+	}
+}
+
 func TestTweet(t *testing.T) {
 	// for comparison methods
 	other := &Tweet{time: time.Date(2018, 1, 1, 0, 0, 0, 0, loc(0))}
@@ -65,6 +85,17 @@ func TestTweet(t *testing.T) {
 				post: "This post contains tabs\t\t\t",
 			},
 		},
+		{
+			String: "2022-01-19T14:15:00+13:00\tMonday, January 2nd 2006 was not a good day",
+			Time:   time.Date(2022, 1, 19, 14, 15, 0, 0, loc(+13)),
+			Post:   "Monday, January 2nd 2006 was not a good day",
+			Before: false,
+			After:  true,
+			twt: &Tweet{
+				time: time.Date(2022, 1, 19, 14, 15, 0, 0, loc(+13)),
+				post: "Monday, January 2nd 2006 was not a good day",
+			},
+		},
 		{
 			String: "2016-02-04T13:30:00+01:00\tYou can really go crazy here! ┐(ﾟ∀ﾟ)┌",
 			Time:   time.Date(2016, 2, 4, 13, 30, 0, 0, loc(+1)),
@@ -133,6 +164,14 @@ func TestTweet(t *testing.T) {
 				}
 			})
 
+			t.Run("Text()", func(t *testing.T) {
+				test := test
+
+				if have, want := test.twt.Text(), test.Post; have != want {
+					t.Errorf("\nhave: %s\nwant: %s", have, want)
+				}
+			})
+
 			t.Run("Post()", func(t *testing.T) {
 				test := test
 
@@ -159,3 +198,221 @@ func TestTweet(t *testing.T) {
 		})
 	}
 }
+
+func TestTweetHash(t *testing.T) {
+	const url = "https://example.org/twtxt.txt"
+
+	tests := []struct {
+		name string
+		hash string
+		twt  *Tweet
+	}{
+		{
+			name: "Mention",
+			hash: "hbdjgiq",
+			twt: &Tweet{
+				time: time.Date(2016, 2, 3, 23, 5, 0, 0, loc(+1)),
+				post: "@<example http://example.org/twtxt.txt> welcome to twtxt!",
+			},
+		},
+		{
+			name: "UTC",
+			hash: "7vmqbxq",
+			twt: &Tweet{
+				time: time.Date(2015, 12, 12, 12, 0, 0, 0, time.UTC),
+				post: "Fiat lux!",
+			},
+		},
+		{
+			name: "EscapedNewline",
+			hash: "7voa4ia",
+			twt: &Tweet{
+				time: time.Date(2022, 1, 19, 14, 14, 0, 0, loc(+13)),
+				post: "This post contains\nline separators",
+			},
+		},
+		{
+			name: "LineSeparator",
+			hash: "peh4baa",
+			twt: &Tweet{
+				time: time.Date(2022, 1, 19, 14, 14, 0, 0, loc(+13)),
+				post: "This post contains\nline separators",
+				raw:  "This post contains\u2028line separators",
+			},
+		},
+		{
+			name: "LoneBackslash",
+			hash: "j4uzzvq",
+			twt: &Tweet{
+				time: time.Date(2016, 2, 4, 13, 30, 0, 0, loc(+1)),
+				post: "C:\\path\\x",
+			},
+		},
+		{
+			name: "EscapedBackslash",
+			hash: "4pzdxtq",
+			twt: &Tweet{
+				time: time.Date(2016, 2, 4, 13, 30, 0, 0, loc(+1)),
+				post: "a \\ b",
+				raw:  "a \\\\ b",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			if have, want := test.twt.Hash(url), test.hash; have != want {
+				t.Errorf("have %q, want %q", have, want)
+			}
+		})
+	}
+
+	// a parsed Tweet is hashed as it is written in the feed, however another
+	// client escaped it
+	lines := map[string]string{
+		"2016-02-04T13:30:00+01:00\tC:\\path\\x": "j4uzzvq",
+		"2016-02-04T13:30:00+01:00\ta \\\\ b":    "4pzdxtq",
+	}
+
+	for line, hash := range lines {
+		file, err := Parse(strings.NewReader(line))
+		if err != nil {
+			t.Fatalf("unexpected error: %q", err)
+		}
+
+		if have := file.Tweets[0].Hash(url); have != hash {
+			t.Errorf("have %q for %q, want %q", have, line, hash)
+		}
+	}
+}
+
+func TestTweetSubject(t *testing.T) {
+	tests := []struct {
+		post    string
+		subject string
+	}{
+		{
+			post:    "Fiat lux!",
+			subject: "",
+		},
+		{
+			post:    "(#hbdjgiq) thanks for the welcome!",
+			subject: "hbdjgiq",
+		},
+		{
+			post:    "(#<hbdjgiq https://example.org/twt/hbdjgiq>) thanks for the welcome!",
+			subject: "hbdjgiq",
+		},
+		{
+			post:    "not a reply (#hbdjgiq)",
+			subject: "",
+		},
+		{
+			post:    "(not a subject) either",
+			subject: "",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.post, func(t *testing.T) {
+			twt := &Tweet{post: test.post}
+
+			if have, want := twt.Subject(), test.subject; have != want {
+				t.Errorf("have %q, want %q", have, want)
+			}
+		})
+	}
+}
+
+func TestTweetMentions(t *testing.T) {
+	tests := []struct {
+		post     string
+		mentions []string
+	}{
+		{
+			post:     "Fiat lux!",
+			mentions: nil,
+		},
+		{
+			post:     "@<bob https://example.org/bob.txt> welcome to twtxt!",
+			mentions: []string{"@<bob https://example.org/bob.txt>"},
+		},
+		{
+			post:     "(#hbdjgiq) @<https://example.org/bob.txt> and @<carol https://example.org/carol.txt>, hi!",
+			mentions: []string{"@<https://example.org/bob.txt>", "@<carol https://example.org/carol.txt>"},
+		},
+		{
+			post:     "email me @ alice@example.org <3",
+			mentions: nil,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.post, func(t *testing.T) {
+			twt := &Tweet{post: test.post}
+
+			var have []string
+			for _, mention := range twt.Mentions() {
+				have = append(have, mention.String())
+			}
+
+			if diff := cmp.Diff(test.mentions, have); diff != "" {
+				t.Errorf("unexpected mentions (-want +have):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTweetBackslashRoundTrip(t *testing.T) {
+	posts := []string{
+		`a literal \n is not a newline`,
+		`C:\Users\alice\new folder`,
+		`a lone backslash \`,
+		"a literal \\\\n and a real\nnewline",
+	}
+
+	for _, post := range posts {
+		post := post
+
+		t.Run(post, func(t *testing.T) {
+			twt := NewTweetAt(post, time.Date(2022, 1, 19, 14, 14, 0, 0, loc(+13)))
+
+			file, err := Parse(strings.NewReader(twt.String()))
+			if err != nil {
+				t.Fatalf("unexpected error: %q", err)
+			}
+
+			if len(file.Tweets) != 1 {
+				t.Fatalf("have %d tweets, want 1", len(file.Tweets))
+			}
+
+			if have := file.Tweets[0].Text(); have != post {
+				t.Errorf("have %q, want %q", have, post)
+			}
+		})
+	}
+}
+
+func TestTweetStringEncodes(t *testing.T) {
+	// the post as written in the feed is only kept for the twt hash
+	file, err := Parse(strings.NewReader("2022-01-19T14:14:00+13:00\tThis post contains\u2028line separators"))
+	if err != nil {
+		t.Fatalf("unexpected error: %q", err)
+	}
+
+	twt := file.Tweets[0]
+
+	if have, want := twt.String(), "2022-01-19T14:14:00+13:00\tThis post contains\\nline separators"; have != want {
+		t.Errorf("\nhave: %q\nwant: %q", have, want)
+	}
+
+	if have, want := twt.Hash("https://example.org/twtxt.txt"), NewTweetAt("This post contains\nline separators", twt.Time()).Hash("https://example.org/twtxt.txt"); have == want {
+		t.Errorf("have the hash of the encoded post %q, want the hash of the post as written", have)
+	}
+}
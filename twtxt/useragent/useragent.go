@@ -0,0 +1,211 @@
+package useragent
+
+import (
+	"errors"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Known clients, the clients that are known to announce their followers.
+const (
+	Twtxt = "twtxt"
+	Twtr  = "twtr"
+	Yarnd = "yarnd"
+	Jenny = "jenny"
+	Tt    = "tt"
+)
+
+// known is the set of Known clients.
+var known = map[string]bool{
+	Twtxt: true,
+	Twtr:  true,
+	Yarnd: true,
+	Jenny: true,
+	Tt:    true,
+}
+
+// Follower is a feed that a client fetches on behalf of.
+type Follower struct {
+	Nick string
+	URL  string
+}
+
+// UserAgent is the parsed User-Agent of a twtxt client, clients announce the
+// feeds that they fetch on behalf of, so that feed owners can see who follows
+// them. Most clients follow the convention of the original twtxt client:
+//
+//     twtxt/1.2.3 (+https://example.org/twtxt.txt; @alice)
+//
+// yarnd announces a single user, or every follower on a multi-user pod:
+//
+//     yarnd/0.13.0@a1b2c3d (~https://pod.example/user/alice/twtxt.txt; contact=https://pod.example/support)
+//     yarnd/0.13.0@a1b2c3d (Pod: pod.example Followers: alice bob Support: https://pod.example/support)
+type UserAgent struct {
+	Client    string
+	Version   string
+	Followers []Follower
+
+	// Pod is the host of a multi-user yarnd pod, and Contact is the support
+	// url of a yarnd pod, they are empty for other clients.
+	Pod     string
+	Contact string
+}
+
+// ErrInvalid is returned by Parse for a User-Agent that does not start with a
+// client and version, e.g. "twtxt/1.2.3".
+var ErrInvalid = errors.New("invalid user agent")
+
+// product matches the client, version, and comment of a User-Agent.
+var product = regexp.MustCompile(`^([^/\s]+)/(\S+)(?:\s+\((.*)\))?`)
+
+// pod matches the comment of a multi-user yarnd pod.
+var pod = regexp.MustCompile(`^Pod:\s*(\S+)\s+Followers:\s*(.*?)\s*(?:Support:\s*(\S+))?$`)
+
+// Parse parses the User-Agent of a twtxt client, clients that don't announce
+// any feeds, including web browsers, are parsed without Followers. Crawlers
+// also put a url after a "+", so a client that is not Known has to announce the
+// nick of the feed too.
+func Parse(ua string) (*UserAgent, error) {
+	match := product.FindStringSubmatch(strings.TrimSpace(ua))
+	if match == nil {
+		return nil, ErrInvalid
+	}
+
+	agent := &UserAgent{
+		Client:  match[1],
+		Version: match[2],
+	}
+
+	comment := strings.TrimSpace(match[3])
+
+	switch {
+	// twtxt/1.2.3 (+https://example.org/twtxt.txt; @alice)
+	case strings.HasPrefix(comment, "+"):
+		parts := strings.Split(comment[1:], ";")
+		follower := Follower{URL: strings.TrimSpace(parts[0])}
+
+		for _, part := range parts[1:] {
+			if part = strings.TrimSpace(part); strings.HasPrefix(part, "@") {
+				follower.Nick = part[1:]
+			}
+		}
+
+		if follower.Nick != "" || agent.Known() {
+			agent.Followers = []Follower{follower}
+		}
+
+	// yarnd/0.13.0@a1b2c3d (~https://pod.example/user/alice/twtxt.txt; contact=...)
+	case strings.HasPrefix(comment, "~"):
+		parts := strings.Split(comment[1:], ";")
+		follower := Follower{URL: strings.TrimSpace(parts[0])}
+		follower.Nick = yarnNick(follower.URL)
+
+		for _, part := range parts[1:] {
+			if part = strings.TrimSpace(part); strings.HasPrefix(part, "contact=") {
+				agent.Contact = strings.TrimPrefix(part, "contact=")
+			}
+		}
+
+		agent.Followers = []Follower{follower}
+
+	// yarnd/0.13.0@a1b2c3d (Pod: pod.example Followers: alice bob Support: ...)
+	case strings.HasPrefix(comment, "Pod:"):
+		match := pod.FindStringSubmatch(comment)
+		if match == nil {
+			break
+		}
+
+		agent.Pod = match[1]
+		agent.Contact = match[3]
+
+		for _, nick := range strings.Fields(match[2]) {
+			agent.Followers = append(agent.Followers, Follower{
+				Nick: nick,
+				URL:  "https://" + agent.Pod + "/user/" + nick + "/twtxt.txt",
+			})
+		}
+	}
+
+	return agent, nil
+}
+
+// yarnNick is a helper to Parse(), it returns the nick in the url of a feed on
+// a yarnd pod, e.g. "alice" for "https://pod.example/user/alice/twtxt.txt".
+func yarnNick(feed string) string {
+	u, err := url.Parse(feed)
+	if err != nil {
+		return ""
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) >= 2 && parts[0] == "user" {
+		return parts[1]
+	}
+
+	return ""
+}
+
+// New creates the UserAgent of a client fetching on behalf of the feed at url,
+// both the nick and url may be empty to not disclose an identity.
+func New(client, version, nick, url string) *UserAgent {
+	agent := &UserAgent{
+		Client:  client,
+		Version: version,
+	}
+
+	if url != "" {
+		agent.Followers = []Follower{{Nick: nick, URL: url}}
+	}
+
+	return agent
+}
+
+// Known reports whether the UserAgent is of one of the Known clients.
+func (agent *UserAgent) Known() bool {
+	return known[agent.Client]
+}
+
+// IsPod reports whether the UserAgent is of a multi-user yarnd pod.
+func (agent *UserAgent) IsPod() bool {
+	return agent.Pod != ""
+}
+
+// String formats the UserAgent as it is sent by the client.
+func (agent *UserAgent) String() string {
+	ua := agent.Client + "/" + agent.Version
+
+	switch {
+	case agent.IsPod():
+		nicks := make([]string, len(agent.Followers))
+		for i, follower := range agent.Followers {
+			nicks[i] = follower.Nick
+		}
+
+		ua += " (Pod: " + agent.Pod + " Followers: " + strings.Join(nicks, " ")
+		if agent.Contact != "" {
+			ua += " Support: " + agent.Contact
+		}
+
+		return ua + ")"
+
+	case len(agent.Followers) == 0:
+		return ua
+
+	case agent.Client == Yarnd:
+		ua += " (~" + agent.Followers[0].URL
+		if agent.Contact != "" {
+			ua += "; contact=" + agent.Contact
+		}
+
+		return ua + ")"
+
+	default:
+		ua += " (+" + agent.Followers[0].URL
+		if agent.Followers[0].Nick != "" {
+			ua += "; @" + agent.Followers[0].Nick
+		}
+
+		return ua + ")"
+	}
+}
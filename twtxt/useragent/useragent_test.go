@@ -0,0 +1,193 @@
+package useragent
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		ua    string
+		want  *UserAgent
+		known bool
+		err   bool
+	}{
+		{
+			name: "Twtxt",
+			ua:   "twtxt/1.2.3 (+https://example.org/twtxt.txt; @alice)",
+			want: &UserAgent{
+				Client:    Twtxt,
+				Version:   "1.2.3",
+				Followers: []Follower{{Nick: "alice", URL: "https://example.org/twtxt.txt"}},
+			},
+			known: true,
+		},
+		{
+			name: "TwtxtAnonymous",
+			ua:   "twtxt/1.2.3",
+			want: &UserAgent{
+				Client:  Twtxt,
+				Version: "1.2.3",
+			},
+			known: true,
+		},
+		{
+			name: "Twtr",
+			ua:   "twtr/v0.0.0 (+https://example.org/bob.txt; @bob)",
+			want: &UserAgent{
+				Client:    Twtr,
+				Version:   "v0.0.0",
+				Followers: []Follower{{Nick: "bob", URL: "https://example.org/bob.txt"}},
+			},
+			known: true,
+		},
+		{
+			name: "Jenny",
+			ua:   "jenny/latest (+https://example.org/carol.txt; @carol)",
+			want: &UserAgent{
+				Client:    Jenny,
+				Version:   "latest",
+				Followers: []Follower{{Nick: "carol", URL: "https://example.org/carol.txt"}},
+			},
+			known: true,
+		},
+		{
+			name: "Tt",
+			ua:   "tt/0.1.0 (+https://example.org/dave.txt; @dave)",
+			want: &UserAgent{
+				Client:    Tt,
+				Version:   "0.1.0",
+				Followers: []Follower{{Nick: "dave", URL: "https://example.org/dave.txt"}},
+			},
+			known: true,
+		},
+		{
+			name: "YarndSingleUser",
+			ua:   "yarnd/0.13.0@a1b2c3d (~https://pod.example/user/erin/twtxt.txt; contact=https://pod.example/support)",
+			want: &UserAgent{
+				Client:    Yarnd,
+				Version:   "0.13.0@a1b2c3d",
+				Followers: []Follower{{Nick: "erin", URL: "https://pod.example/user/erin/twtxt.txt"}},
+				Contact:   "https://pod.example/support",
+			},
+			known: true,
+		},
+		{
+			name: "YarndPod",
+			ua:   "yarnd/0.13.0@a1b2c3d (Pod: pod.example Followers: erin frank Support: https://pod.example/support)",
+			want: &UserAgent{
+				Client:  Yarnd,
+				Version: "0.13.0@a1b2c3d",
+				Followers: []Follower{
+					{Nick: "erin", URL: "https://pod.example/user/erin/twtxt.txt"},
+					{Nick: "frank", URL: "https://pod.example/user/frank/twtxt.txt"},
+				},
+				Pod:     "pod.example",
+				Contact: "https://pod.example/support",
+			},
+			known: true,
+		},
+		{
+			name: "Browser",
+			ua:   "Mozilla/5.0 (X11; Linux x86_64; rv:98.0) Gecko/20100101 Firefox/98.0",
+			want: &UserAgent{
+				Client:  "Mozilla",
+				Version: "5.0",
+			},
+		},
+		{
+			name: "Crawler",
+			ua:   "facebookexternalhit/1.1 (+http://www.facebook.com/externalhit_uatext.php)",
+			want: &UserAgent{
+				Client:  "facebookexternalhit",
+				Version: "1.1",
+			},
+		},
+		{
+			name: "UnknownClient",
+			ua:   "newclient/0.1.0 (+https://example.org/grace.txt; @grace)",
+			want: &UserAgent{
+				Client:    "newclient",
+				Version:   "0.1.0",
+				Followers: []Follower{{Nick: "grace", URL: "https://example.org/grace.txt"}},
+			},
+		},
+		{
+			name: "Empty",
+			ua:   "",
+			err:  true,
+		},
+		{
+			name: "NoVersion",
+			ua:   "curl",
+			err:  true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			have, err := Parse(test.ua)
+
+			if (err != nil) != test.err {
+				t.Fatalf("err = %v, want error %t", err, test.err)
+			}
+
+			if diff := cmp.Diff(test.want, have); diff != "" {
+				t.Errorf("unexpected user agent (-want +have):\n%s", diff)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if have := have.Known(); have != test.known {
+				t.Errorf("Known() = %t, want %t", have, test.known)
+			}
+
+			// known clients are formatted exactly as they were sent
+			if test.known {
+				if diff := cmp.Diff(test.ua, have.String()); diff != "" {
+					t.Errorf("unexpected String() (-want +have):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name  string
+		agent *UserAgent
+		want  string
+	}{
+		{
+			name:  "DiscloseIdentity",
+			agent: New(Twtr, "v0.0.0", "alice", "https://example.org/twtxt.txt"),
+			want:  "twtr/v0.0.0 (+https://example.org/twtxt.txt; @alice)",
+		},
+		{
+			name:  "URLOnly",
+			agent: New(Twtr, "v0.0.0", "", "https://example.org/twtxt.txt"),
+			want:  "twtr/v0.0.0 (+https://example.org/twtxt.txt)",
+		},
+		{
+			name:  "Anonymous",
+			agent: New(Twtr, "v0.0.0", "", ""),
+			want:  "twtr/v0.0.0",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			if have := test.agent.String(); have != test.want {
+				t.Errorf("have %q, want %q", have, test.want)
+			}
+		})
+	}
+}